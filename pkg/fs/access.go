@@ -0,0 +1,145 @@
+package fs
+
+import (
+	"context"
+	"path"
+	"runtime"
+
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+// The bits of the mask passed to Access.
+const (
+	accessExec  = 1
+	accessWrite = 2
+	accessRead  = 4
+)
+
+// WithAccessChecks makes the file system check the permissions of the caller of each call
+// against the owner and mode that are reported for the file or directory. By default, the
+// kernel does these checks using all groups of the caller. The file system only knows the
+// primary group of the caller, so members of a supplementary group are denied access that the
+// kernel would grant, and each call that adds or removes an entry looks up its directory. A
+// FuseHost mounts a file system that checks access itself without "-o default_permissions".
+func WithAccessChecks(on bool) Option {
+	return func(f *fuseImpl) {
+		f.accessChecks = on
+	}
+}
+
+// accessCheckingFS is implemented by file systems that may check the permissions of the caller
+// themselves.
+type accessCheckingFS interface {
+	checksAccess() bool
+}
+
+func (f *fuseImpl) checksAccess() bool {
+	return f.accessChecks
+}
+
+// getcontext returns the uid, gid, and pid of the process that made the current FUSE call.
+// Tests replace it, because the FUSE context is only available in a FUSE call.
+var getcontext = fuse.Getcontext
+
+// caller returns the uid and gid of the process that made the current FUSE call. The ok result
+// is false when they are unknown, which is the case when the file system isn't mounted, and on
+// Windows where WinFsp maps the caller's security identifiers itself.
+func (f *fuseImpl) caller() (uid, gid uint32, ok bool) {
+	if !f.mounted.Load() || runtime.GOOS == "windows" {
+		return 0, 0, false
+	}
+	uid, gid, _ = getcontext()
+	return uid, gid, true
+}
+
+// Access checks whether the caller has the permissions of the given mask on the path. The
+// permissions are only checked when WithAccessChecks is used, because the kernel doesn't call
+// Access otherwise.
+func (f *fuseImpl) Access(path string, mask uint32) (errCode int) {
+	ctx, end := f.startOp("Access", path)
+	defer end(&errCode)
+	f.logger().Debugf("Access(%s, %#o)", path, mask)
+	e, errCode := f.getEntry(ctx, path)
+	if errCode != 0 {
+		return errCode
+	}
+	if mask&accessWrite != 0 {
+		if errCode = f.denyWrite(); errCode != 0 {
+			return errCode
+		}
+	}
+	return f.checkAccess(e, mask)
+}
+
+// checkAccess returns -EACCES unless the caller has the permissions of the given mask on the
+// entry, as reported by Getattr, or access checks are disabled. Only the primary group of the
+// caller is considered, because the FUSE context doesn't include the supplementary groups.
+func (f *fuseImpl) checkAccess(e *entry, mask uint32) int {
+	if !f.accessChecks {
+		return 0
+	}
+	uid, gid, ok := f.caller()
+	if !ok || mask == 0 {
+		return 0
+	}
+	s := &fuse.Stat_t{}
+	toStat(&e.Entry, s)
+	f.identity.apply(e.facts, s)
+	if !permits(s, uid, gid, mask) {
+		f.logger().Debugf("uid %d, gid %d is denied access %#o to %s", uid, gid, mask, e.Name)
+		return -fuse.EACCES
+	}
+	return 0
+}
+
+// checkParentAccess returns -EACCES unless the caller may add and remove entries in the
+// directory that contains the given path, or access checks are disabled.
+func (f *fuseImpl) checkParentAccess(ctx context.Context, p string) int {
+	if !f.accessChecks {
+		return 0
+	}
+	if _, _, ok := f.caller(); !ok {
+		return 0
+	}
+	e, errCode := f.getEntry(ctx, path.Dir(p))
+	if errCode != 0 {
+		return errCode
+	}
+	return f.checkAccess(e, accessWrite|accessExec)
+}
+
+// openMask returns the access mask that is required to open a file using the given flags.
+func openMask(flags int) uint32 {
+	var mask uint32
+	switch flags & fuse.O_ACCMODE {
+	case fuse.O_RDONLY:
+		mask = accessRead
+	case fuse.O_WRONLY:
+		mask = accessWrite
+	default:
+		mask = accessRead | accessWrite
+	}
+	if flags&fuse.O_TRUNC != 0 {
+		mask |= accessWrite
+	}
+	return mask
+}
+
+// permits returns true if the given user and group have the permissions of the given mask
+// according to the stat. The root user may read and write everything, and execute everything
+// that has an execute bit set.
+func permits(s *fuse.Stat_t, uid, gid, mask uint32) bool {
+	if uid == 0 {
+		return mask&accessExec == 0 || s.Mode&fuse.S_IFMT == fuse.S_IFDIR || s.Mode&0111 != 0
+	}
+	var perm uint32
+	switch {
+	case uid == s.Uid:
+		perm = s.Mode >> 6
+	case gid == s.Gid:
+		perm = s.Mode >> 3
+	default:
+		perm = s.Mode
+	}
+	return perm&mask == mask
+}
@@ -0,0 +1,136 @@
+package fs
+
+import (
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestPermits(t *testing.T) {
+	file := &fuse.Stat_t{Mode: fuse.S_IFREG | 0640, Uid: 1000, Gid: 100}
+	assert.True(t, permits(file, 1000, 1000, accessRead|accessWrite))
+	assert.False(t, permits(file, 1000, 1000, accessExec))
+	assert.True(t, permits(file, 2000, 100, accessRead))
+	assert.False(t, permits(file, 2000, 100, accessWrite))
+	assert.False(t, permits(file, 2000, 200, accessRead))
+	assert.True(t, permits(file, 0, 0, accessRead|accessWrite))
+	assert.False(t, permits(file, 0, 0, accessExec))
+
+	dir := &fuse.Stat_t{Mode: fuse.S_IFDIR | 0700, Uid: 1000, Gid: 100}
+	assert.True(t, permits(dir, 0, 0, accessWrite|accessExec))
+	assert.False(t, permits(dir, 2000, 100, accessExec))
+
+	assert.Equal(t, uint32(accessRead), openMask(fuse.O_RDONLY))
+	assert.Equal(t, uint32(accessRead|accessWrite), openMask(fuse.O_RDONLY|fuse.O_TRUNC))
+	assert.Equal(t, uint32(accessWrite), openMask(fuse.O_WRONLY))
+	assert.Equal(t, uint32(accessRead|accessWrite), openMask(fuse.O_RDWR))
+}
+
+func TestAccess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the caller is unknown on Windows")
+	}
+	owner := uint32(1000)
+	f, root := newTestClient(t,
+		WithAccessChecks(true),
+		WithIdentity(Identity{UID: &owner, GID: &owner}),
+		WithUserCredentials(map[uint32]Credentials{2000: {User: "anonymous", Password: "user2000@example.com"}}))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), []byte("Some text\n"), 0644))
+
+	// Pretend that the file system is mounted and that the calls are made by the given uid.
+	var uid uint32
+	orig := getcontext
+	getcontext = func() (uint32, uint32, int) { return uid, uid, 0 }
+	t.Cleanup(func() { getcontext = orig })
+	f.mounted.Store(true)
+
+	// Other users may read, but not write
+	uid = 3000
+	assert.Equal(t, 0, f.Access("/test1.txt", accessRead))
+	assert.Equal(t, -fuse.EACCES, f.Access("/test1.txt", accessWrite))
+	ec, fh := f.Open("/test1.txt", fuse.O_RDONLY)
+	require.Equal(t, 0, ec)
+	assert.Equal(t, 0, f.Release("/test1.txt", fh))
+	ec, _ = f.Open("/test1.txt", fuse.O_WRONLY)
+	assert.Equal(t, -fuse.EACCES, ec)
+	ec, _ = f.Create("/new.txt", fuse.O_WRONLY, 0644)
+	assert.Equal(t, -fuse.EACCES, ec)
+	assert.Equal(t, -fuse.EACCES, f.Mkdir("/sub", 0755))
+	assert.Equal(t, -fuse.EACCES, f.Unlink("/test1.txt"))
+	assert.Equal(t, -fuse.EACCES, f.Rename("/test1.txt", "/test2.txt"))
+	assert.Equal(t, -fuse.EACCES, f.Truncate("/test1.txt", 0, math.MaxUint64))
+	_, err := os.Stat(filepath.Join(root, "new.txt"))
+	assert.True(t, os.IsNotExist(err))
+
+	// The owner may write
+	uid = owner
+	assert.Equal(t, 0, f.Access("/test1.txt", accessRead|accessWrite))
+	assert.Equal(t, 0, f.Mkdir("/sub", 0755))
+	assert.Equal(t, 0, f.Rename("/test1.txt", "/sub/test1.txt"))
+	assert.FileExists(t, filepath.Join(root, "sub", "test1.txt"))
+	assert.Empty(t, f.userPools)
+
+	// A user with credentials of their own gets a separate pool
+	dials := f.Stats().Pool.Dials
+	uid = 2000
+	assert.Equal(t, 0, f.Access("/sub/test1.txt", accessRead))
+	require.Len(t, f.userPools, 1)
+	assert.Greater(t, f.Stats().Pool.Dials, dials)
+	assert.Equal(t, 0, f.Access("/sub", accessRead|accessExec))
+	assert.Len(t, f.pools(), 2)
+
+	// Without access checks, the kernel checks the permissions
+	f.accessChecks = false
+	uid = 3000
+	assert.Equal(t, 0, f.Access("/sub/test1.txt", accessWrite))
+	assert.Equal(t, 0, f.Mkdir("/sub2", 0755))
+	assert.DirExists(t, filepath.Join(root, "sub2"))
+}
+
+func TestUserPoolDial(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the caller is unknown on Windows")
+	}
+	// The server never replies to the CWD, so the pool of the user cannot be created until the
+	// control timeout has passed.
+	f := &fuseImpl{
+		pool: connPool{
+			addr:     startHangingServer(t),
+			dir:      "hangs",
+			timeouts: Timeouts{}.withDefaults(time.Second),
+			dialer:   &net.Dialer{},
+		},
+		userCredentials: map[uint32]Credentials{2000: {User: "user2000"}},
+	}
+	orig := getcontext
+	getcontext = func() (uint32, uint32, int) { return 2000, 2000, 0 }
+	t.Cleanup(func() { getcontext = orig })
+	f.mounted.Store(true)
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := f.callerPool()
+			errs <- err
+		}()
+	}
+
+	// The other pools remain available while the pool of the user is created
+	time.Sleep(100 * time.Millisecond)
+	start := time.Now()
+	assert.Len(t, f.pools(), 1)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	assert.Error(t, <-errs)
+	assert.Error(t, <-errs)
+	assert.Empty(t, f.userPools)
+	assert.Empty(t, f.userPoolDials)
+}
@@ -0,0 +1,176 @@
+package fs
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultAttrCacheTTL is the time that an entry returned by Readdir is used to answer lookups
+// unless WithAttrCacheTTL says otherwise.
+const defaultAttrCacheTTL = stalePeriod
+
+// WithAttrCacheTTL sets the time that the attributes returned by Readdir are used to answer the
+// lookups that follow it. The time is measured from the end of the listing. Zero means the
+// default, which is one second, and a negative value disables the cache.
+func WithAttrCacheTTL(ttl time.Duration) Option {
+	return func(f *fuseImpl) {
+		f.attrs.ttl = ttl
+	}
+}
+
+// SetAttrCacheTTL changes the time that the attributes returned by Readdir are used to answer
+// lookups. The change applies to the attributes that are already cached.
+func (f *fuseImpl) SetAttrCacheTTL(ttl time.Duration) {
+	f.attrs.setTTL(ttl)
+}
+
+// attrCache holds the entries that Readdir has returned recently, so that the lookups that the
+// kernel makes for them, e.g. during "ls -l", are answered without an MLST for each entry. The
+// entries are kept separately for each connection pool, because the users of different pools
+// may see different things on the FTP server. They expire when the TTL has passed since the end
+// of the listing that returned them, and are dropped when they are modified using the file
+// system. The zero value is ready to use.
+type attrCache struct {
+	sync.Mutex
+	ttl  time.Duration
+	dirs map[dirKey]*cachedDir
+}
+
+type dirKey struct {
+	pool *connPool
+	dir  string
+}
+
+// cachedDir holds the entries of one directory by name. The listed time is that of the entry
+// that was listed last.
+type cachedDir struct {
+	listed  time.Time
+	entries map[string]cachedEntry
+}
+
+// cachedEntry is an entry together with the listing that returned it. The listed time is the
+// time that the entry was returned, and is moved to the end of the listing when it ends.
+type cachedEntry struct {
+	*entry
+	listing *dirListing
+	listed  time.Time
+}
+
+func (c *attrCache) setTTL(ttl time.Duration) {
+	c.Lock()
+	c.ttl = ttl
+	c.Unlock()
+}
+
+// ttlLocked returns the TTL of the entries, or zero when the cache is disabled.
+func (c *attrCache) ttlLocked() time.Duration {
+	switch {
+	case c.ttl == 0:
+		return defaultAttrCacheTTL
+	case c.ttl < 0:
+		return 0
+	default:
+		return c.ttl
+	}
+}
+
+// put adds an entry of the given directory, returned by the given listing of the given pool.
+func (c *attrCache) put(p *connPool, dir string, l *dirListing, e *entry) {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+	if c.ttlLocked() == 0 {
+		return
+	}
+	k := dirKey{pool: p, dir: dir}
+	d, ok := c.dirs[k]
+	if !ok {
+		c.pruneLocked(now)
+		if c.dirs == nil {
+			c.dirs = make(map[dirKey]*cachedDir)
+		}
+		d = &cachedDir{entries: make(map[string]cachedEntry)}
+		c.dirs[k] = d
+	}
+	d.listed = now
+	d.entries[e.Name] = cachedEntry{entry: e, listing: l, listed: now}
+}
+
+// ended marks the end of the given listing of the given directory, so that the TTL of the entries
+// that it returned starts now.
+func (c *attrCache) ended(p *connPool, dir string, l *dirListing) {
+	now := time.Now()
+	c.Lock()
+	defer c.Unlock()
+	d, ok := c.dirs[dirKey{pool: p, dir: dir}]
+	if !ok {
+		return
+	}
+	for name, ce := range d.entries {
+		if ce.listing == l {
+			ce.listed = now
+			d.entries[name] = ce
+			d.listed = now
+		}
+	}
+}
+
+// get returns the entry of the given path that was listed using the given pool, or nil when it
+// isn't cached or has expired.
+func (c *attrCache) get(p *connPool, fp string) *entry {
+	dir, name := path.Split(fp)
+	if name == "" {
+		return nil
+	}
+	dir = path.Clean(dir)
+	c.Lock()
+	defer c.Unlock()
+	if d, ok := c.dirs[dirKey{pool: p, dir: dir}]; ok {
+		if ce, ok := d.entries[name]; ok && time.Since(ce.listed) < c.ttlLocked() {
+			return ce.entry
+		}
+	}
+	return nil
+}
+
+// remove drops the entry of the given path, and the entries of everything below it, from the
+// entries of all pools.
+func (c *attrCache) remove(p string) {
+	c.Lock()
+	defer c.Unlock()
+	parent, name := path.Dir(p), path.Base(p)
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	for k, d := range c.dirs {
+		if k.dir == parent {
+			delete(d.entries, name)
+		}
+		if k.dir == p || strings.HasPrefix(k.dir, prefix) {
+			delete(c.dirs, k)
+		}
+	}
+}
+
+// prune drops the directories that only have expired entries.
+func (c *attrCache) prune() {
+	c.Lock()
+	c.pruneLocked(time.Now())
+	c.Unlock()
+}
+
+func (c *attrCache) pruneLocked(now time.Time) {
+	ttl := c.ttlLocked()
+	for k, d := range c.dirs {
+		if now.Sub(d.listed) >= ttl {
+			delete(c.dirs, k)
+		}
+	}
+}
+
+// reset drops all entries.
+func (c *attrCache) reset() {
+	c.Lock()
+	c.dirs = nil
+	c.Unlock()
+}
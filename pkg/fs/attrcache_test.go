@@ -0,0 +1,170 @@
+package fs
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestAttrCache(t *testing.T) {
+	var c attrCache
+	p := &connPool{}
+	l := &dirListing{}
+	a := &entry{Entry: ftp.Entry{Name: "a"}}
+	d := &entry{Entry: ftp.Entry{Name: "d", Type: ftp.EntryTypeFolder}}
+	b := &entry{Entry: ftp.Entry{Name: "b"}}
+	c.put(p, "/", l, a)
+	c.put(p, "/", l, d)
+	c.put(p, "/d", l, b)
+	assert.Equal(t, a, c.get(p, "/a"))
+	assert.Equal(t, b, c.get(p, "/d/b"))
+	assert.Nil(t, c.get(p, "/b"))
+	assert.Nil(t, c.get(p, "/"))
+
+	// Entries are only returned to users of the pool that listed them
+	assert.Nil(t, c.get(&connPool{}, "/a"))
+
+	// Removing a directory drops everything below it
+	c.remove("/d")
+	assert.Nil(t, c.get(p, "/d"))
+	assert.Nil(t, c.get(p, "/d/b"))
+	assert.Equal(t, a, c.get(p, "/a"))
+
+	c.reset()
+	assert.Nil(t, c.get(p, "/a"))
+
+	// Entries expire when the TTL has passed since the end of their listing
+	c.setTTL(time.Hour)
+	c.put(p, "/", l, a)
+	c.put(p, "/", &dirListing{}, b)
+	c.Lock()
+	c.dirs[dirKey{pool: p, dir: "/"}].entries["a"] = cachedEntry{entry: a, listing: l, listed: time.Now().Add(-2 * time.Hour)}
+	c.dirs[dirKey{pool: p, dir: "/"}].entries["b"] = cachedEntry{entry: b, listing: l, listed: time.Now().Add(-2 * time.Hour)}
+	c.Unlock()
+	assert.Nil(t, c.get(p, "/a"))
+	c.ended(p, "/", l)
+	assert.Equal(t, a, c.get(p, "/a"))
+	assert.Equal(t, b, c.get(p, "/b"))
+
+	// A change of the TTL applies to cached entries
+	c.setTTL(time.Nanosecond)
+	assert.Nil(t, c.get(p, "/a"))
+	c.prune()
+	assert.Empty(t, c.dirs)
+
+	// A negative TTL disables the cache
+	c.setTTL(-1)
+	c.put(p, "/", l, a)
+	assert.Nil(t, c.get(p, "/a"))
+	assert.Empty(t, c.dirs)
+}
+
+// countCommands relays connections from a local port to the given FTP server, and counts the
+// commands that are sent to the server using them.
+func countCommands(t *testing.T, server netip.AddrPort) (netip.AddrPort, *atomic.Int64) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	var count atomic.Int64
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				s, err := net.Dial("tcp", server.String())
+				if err != nil {
+					return
+				}
+				defer s.Close()
+				go func() {
+					_, _ = io.Copy(c, s)
+					_ = c.Close()
+				}()
+				sc := bufio.NewScanner(c)
+				for sc.Scan() {
+					count.Add(1)
+					if _, err := s.Write(append(sc.Bytes(), '\r', '\n')); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return netip.MustParseAddrPort(l.Addr().String()), &count
+}
+
+func TestReaddirLookups(t *testing.T) {
+	root, addr := startTestServer(t)
+	const count = 1000
+	for i := 0; i < count; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(root, fmt.Sprintf("file%04d.txt", i)), []byte("Some text\n"), 0644))
+	}
+
+	relay, commands := countCommands(t, addr)
+	f := connectTestClient(t, relay, WithAttrCacheTTL(time.Minute))
+
+	// Perform the calls that the kernel makes during "ls -l": list the directory, and then look
+	// up each entry.
+	lsl := func() map[string]fuse.Stat_t {
+		errCode, fh := f.Opendir("/")
+		require.Equal(t, 0, errCode)
+		defer f.Releasedir("/", fh)
+		var names []string
+		require.Equal(t, 0, f.Readdir("/", func(name string, _ *fuse.Stat_t, _ int64) bool {
+			names = append(names, name)
+			return true
+		}, 0, fh))
+		stats := make(map[string]fuse.Stat_t, len(names))
+		for _, name := range names {
+			s := fuse.Stat_t{}
+			require.Equal(t, 0, f.Getattr("/"+name, &s, math.MaxUint64))
+			stats[name] = s
+		}
+		return stats
+	}
+
+	before := commands.Load()
+	stats := lsl()
+	require.Len(t, stats, count)
+	used := commands.Load() - before
+	t.Logf("ls -l of %d entries sent %d commands to the server", count, used)
+	// An MLST for the Opendir, and the commands of one MLSD. Some leeway is given for
+	// connections that the pool dials in the meantime. The TTL is long enough for the entries
+	// to be cached until all lookups are done.
+	assert.Less(t, used, int64(20))
+	assert.Equal(t, int64(10), stats["file0000.txt"].Size)
+	assert.Equal(t, uint32(fuse.S_IFREG), stats["file0000.txt"].Mode&fuse.S_IFMT)
+
+	// Entries that are modified using the file system are looked up again
+	require.Equal(t, 0, f.Unlink("/file0001.txt"))
+	assert.Equal(t, -fuse.ENOENT, f.Getattr("/file0001.txt", &fuse.Stat_t{}, math.MaxUint64))
+	errCode, fh := f.Open("/file0002.txt", fuse.O_WRONLY|fuse.O_APPEND)
+	require.Equal(t, 0, errCode)
+	require.Equal(t, 5, f.Write("/file0002.txt", []byte("more\n"), 10, fh))
+	require.Equal(t, 0, f.Release("/file0002.txt", fh))
+	s := &fuse.Stat_t{}
+	require.Equal(t, 0, f.Getattr("/file0002.txt", s, math.MaxUint64))
+	assert.Equal(t, int64(15), s.Size)
+
+	// Entries expire
+	f.SetAttrCacheTTL(time.Nanosecond)
+	before = commands.Load()
+	require.Equal(t, 0, f.Getattr("/file0003.txt", s, math.MaxUint64))
+	assert.Greater(t, commands.Load(), before)
+}
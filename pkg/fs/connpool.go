@@ -1,46 +1,213 @@
 package fs
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"net"
 	"net/netip"
-	"strings"
+	"net/textproto"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jlaffaye/ftp"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/net/proxy"
 )
 
+// ftpConn is a control connection to the FTP server, along with the state that the
+// connPool maintains for it.
+type ftpConn struct {
+	*ftp.ServerConn
+
+	// opDeadline is the deadline, in unix nanoseconds, of the operation that currently
+	// uses the connection, or zero when no deadline is in effect. It applies to the
+	// control connection and to all data connections that it opens.
+	opDeadline atomic.Int64
+
+	// aborted is set when the connection has been aborted or found broken. Such a connection is
+	// reset when it is returned to the pool, and replaced with a new connection if that fails.
+	aborted atomic.Bool
+
+	// broken is set when the control connection has failed or been closed. A broken connection
+	// cannot be reset.
+	broken atomic.Bool
+
+	// control is the control connection.
+	control net.Conn
+
+	// dataLock protects data
+	dataLock sync.Mutex
+
+	// data is the data connection that is open, or nil when no data connection is open.
+	data net.Conn
+
+	// gen is the generation of the pool's settings that the connection was created with.
+	gen uint64
+
+	// controlTap and dataTap receive the data read from the control connection and the
+	// data connections while they are set. See capture and withTap.
+	controlTap atomic.Pointer[tapFunc]
+	dataTap    atomic.Pointer[tapFunc]
+}
+
+// tapFunc receives the data that is read from a connection.
+type tapFunc func([]byte)
+
+// abortTimeout is the time that the FTP server is given to reply after a transfer has been
+// aborted, and to reply to the ABOR that resets the connection.
+const abortTimeout = time.Second
+
+// abort ends the command that is in progress without waiting for it to complete. The method is
+// safe to call concurrently with the command. The data connection is closed, which ends the
+// transfer, so that the server replies to the command within abortTimeout. When no data
+// connection is open, the command waits for a reply on the control connection that ABOR cannot
+// speed up, so the connection is closed instead. The connection must be returned to the pool,
+// which resets it using ABOR before it is used again.
+//
+// ABOR isn't sent here, because the replies to it would reach the ftp package in the middle of
+// the command that is in progress.
+func (c *ftpConn) abort() {
+	c.aborted.Store(true)
+	c.dataLock.Lock()
+	data := c.data
+	c.dataLock.Unlock()
+	if data == nil {
+		c.close()
+		return
+	}
+	_ = data.Close()
+	dl := time.Now().Add(abortTimeout)
+	if od := c.opDeadline.Load(); od == 0 || od > dl.UnixNano() {
+		c.opDeadline.Store(dl.UnixNano())
+	}
+	_ = c.control.SetReadDeadline(dl)
+}
+
+// close closes the control connection and the data connection. Any command that is in progress
+// returns with an error, and the connection cannot be used again.
+func (c *ftpConn) close() {
+	c.broken.Store(true)
+	c.dataLock.Lock()
+	data := c.data
+	c.dataLock.Unlock()
+	if data != nil {
+		_ = data.Close()
+	}
+	_ = c.control.Close()
+}
+
+// reset makes an aborted connection usable again. It sends ABOR, so that the server ends any
+// transfer that it still considers to be in progress, and reads the replies, which are a 426 for
+// such a transfer followed by a 225 or 226 for the ABOR. It returns false if the connection is
+// broken or the server doesn't reply as expected.
+func (c *ftpConn) reset() bool {
+	if c.broken.Load() {
+		return false
+	}
+	c.opDeadline.Store(time.Now().Add(abortTimeout).UnixNano())
+	defer c.opDeadline.Store(0)
+	if _, err := c.control.Write([]byte("ABOR\r\n")); err != nil {
+		return false
+	}
+	// The ftp package has read the reply to the last command, so nothing is buffered by it.
+	r := textproto.NewReader(bufio.NewReader(c.control))
+	for {
+		code, _, err := r.ReadResponse(0)
+		switch {
+		case err != nil:
+			return false
+		case code == ftp.StatusDataConnectionOpen || code == ftp.StatusClosingDataConnection:
+			c.aborted.Store(false)
+			return true
+		case code/100 != 4:
+			return false
+		}
+	}
+}
+
+// dataConn is a data connection that is forgotten by its ftpConn when it is closed.
+type dataConn struct {
+	net.Conn
+	fc *ftpConn
+}
+
+func (d *dataConn) Close() error {
+	d.fc.dataLock.Lock()
+	if d.fc.data == d {
+		d.fc.data = nil
+	}
+	d.fc.dataLock.Unlock()
+	return d.Conn.Close()
+}
+
 type connList struct {
-	conn *ftp.ServerConn
+	conn *ftpConn
 	next *connList
 }
 
+// timedConn is a net.Conn that sets a new deadline prior to each Read and Write. The
+// deadline is the given timeout from now, or the operation deadline if that comes first.
+// All data that is read is also passed to the tap while one is set.
 type timedConn struct {
 	net.Conn
-	timeout time.Duration
+	timeout    time.Duration
+	opDeadline *atomic.Int64
+	tap        *atomic.Pointer[tapFunc]
+
+	// failed, when not nil, is set when a Read or Write fails.
+	failed *atomic.Bool
+}
+
+func (t *timedConn) deadline() time.Time {
+	var dl time.Time
+	if t.timeout > 0 {
+		dl = time.Now().Add(t.timeout)
+	}
+	if od := t.opDeadline.Load(); od != 0 {
+		if odt := time.Unix(0, od); dl.IsZero() || odt.Before(dl) {
+			dl = odt
+		}
+	}
+	return dl
 }
 
-func (t timedConn) Read(b []byte) (n int, err error) {
-	if err := t.SetReadDeadline(time.Now().Add(t.timeout)); err != nil {
+func (t *timedConn) Read(b []byte) (n int, err error) {
+	if err := t.SetReadDeadline(t.deadline()); err != nil {
 		return 0, err
 	}
-	return t.Conn.Read(b)
+	n, err = t.Conn.Read(b)
+	if n > 0 {
+		if tap := t.tap.Load(); tap != nil {
+			(*tap)(b[:n])
+		}
+	}
+	if err != nil && t.failed != nil {
+		t.failed.Store(true)
+	}
+	return n, err
 }
 
-func (t timedConn) Write(b []byte) (n int, err error) {
-	if err := t.SetWriteDeadline(time.Now().Add(t.timeout)); err != nil {
+func (t *timedConn) Write(b []byte) (n int, err error) {
+	if err := t.SetWriteDeadline(t.deadline()); err != nil {
 		return 0, err
 	}
-	return t.Conn.Write(b)
+	n, err = t.Conn.Write(b)
+	if err != nil && t.failed != nil {
+		t.failed.Store(true)
+	}
+	return n, err
 }
 
-func (cl *connList) conns() []*ftp.ServerConn {
+func (cl *connList) conns() []*ftpConn {
 	sz := cl.size()
 	if sz == 0 {
 		return nil
 	}
-	cs := make([]*ftp.ServerConn, sz)
+	cs := make([]*ftpConn, sz)
 	i := 0
 	for c := cl; c != nil; c = c.next {
 		cs[i] = c.conn
@@ -57,55 +224,166 @@ func (cl *connList) size() int {
 	return sz
 }
 
+// connSettings are the settings that are used when a connection is created. A connection that
+// was created using settings that have since been changed is discarded when it is returned to
+// the pool.
+type connSettings struct {
+	addr        netip.AddrPort
+	dir         string
+	timeouts    Timeouts
+	credentials Credentials
+}
+
 type connPool struct {
 	sync.Mutex
-	addr     netip.AddrPort
-	dir      string
-	timeout  time.Duration
+	addr        netip.AddrPort
+	dir         string
+	timeouts    Timeouts
+	credentials Credentials
+
+	// gen is incremented when the connection settings change
+	gen uint64
+
+	// updateLock serializes changes of the connection settings
+	updateLock sync.Mutex
+
+	limits   PoolLimits
+	proxyURL string
+	dialer   proxy.ContextDialer
 	idleList *connList
 	busyList *connList
+	closed   bool
+	onEvent  EventHandler
+
+	// lost is true when the last attempt to reach the FTP server failed
+	lost bool
+
+	// dials and dialFailures count the attempts to connect to the FTP server
+	dials        atomic.Uint64
+	dialFailures atomic.Uint64
+
+	tracer trace.Tracer
+
+	// replies counts the reply codes of the FTP commands that fail. Nothing is counted when
+	// it is nil.
+	replies *stats
+
+	// log is the logger of the pool. The standard logger is used when it is nil.
+	log *log.Entry
+}
+
+// dial dials a connection to the given address. The timeout is the idle timeout
+// used for each Read and Write on the returned connection, the tap receives the
+// data that is read while it is set, and failed, unless nil, is set when a Read
+// or Write fails.
+func (p *connPool) dial(network, address string, dialTimeout, timeout time.Duration, opDeadline *atomic.Int64, tap *atomic.Pointer[tapFunc], failed *atomic.Bool) (net.Conn, error) {
+	ctx := context.Background()
+	if dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, dialTimeout)
+		defer cancel()
+	}
+	if od := opDeadline.Load(); od != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, time.Unix(0, od))
+		defer cancel()
+	}
+	conn, err := p.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &timedConn{Conn: conn, timeout: timeout, opDeadline: opDeadline, tap: tap, failed: failed}, nil
 }
 
 // connect returns a new connection without using the pool. Use get instead of connect.
-func (p *connPool) connect() (*ftp.ServerConn, error) {
-	var opts []ftp.DialOption
-	if p.timeout > 0 {
-		opts = append(opts,
-			ftp.DialWithTimeout(p.timeout),
-			ftp.DialWithShutTimeout(p.timeout),
-			ftp.DialWithDialFunc(func(network, address string) (net.Conn, error) {
-				conn, err := net.DialTimeout(network, address, p.timeout)
-				if err != nil {
-					return nil, err
-				}
-				return &timedConn{Conn: conn, timeout: p.timeout}, nil
-			}))
-	}
-	conn, err := ftp.Dial(p.addr.String(), opts...)
+func (p *connPool) connect(ctx context.Context) (*ftpConn, error) {
+	p.Lock()
+	s, gen := p.settings(), p.gen
+	p.Unlock()
+	return p.connectWith(ctx, s, gen, true)
+}
+
+// settings returns the current connSettings. The pool must be locked.
+func (p *connPool) settings() connSettings {
+	return connSettings{addr: p.addr, dir: p.dir, timeouts: p.timeouts, credentials: p.credentials}
+}
+
+// connectWith returns a new connection that is created using the given settings, and that
+// belongs to the given generation of the pool. The connection is added to the busy list. A
+// failure to reach the server is reported as a lost connection when reportLoss is true.
+func (p *connPool) connectWith(ctx context.Context, s connSettings, gen uint64, reportLoss bool) (fc *ftpConn, err error) {
+	_, span := p.getTracer().Start(ctx, "ftp.connect", trace.WithAttributes(attribute.String("ftp.address", s.addr.String())))
+	defer func() { endSpan(span, err) }()
+	p.dials.Add(1)
+	to := s.timeouts
+	fc = &ftpConn{gen: gen}
+	controlDialed := false
+	opts := []ftp.DialOption{
+		// The first connection dialed is the control connection. All subsequent
+		// connections are data connections.
+		ftp.DialWithDialFunc(func(network, address string) (net.Conn, error) {
+			if !controlDialed {
+				controlDialed = true
+				conn, err := p.dial(network, address, to.Dial, to.Control, &fc.opDeadline, &fc.controlTap, &fc.broken)
+				fc.control = conn
+				return conn, err
+			}
+			conn, err := p.dial(network, address, to.Dial, to.DataIdle, &fc.opDeadline, &fc.dataTap, nil)
+			if err != nil {
+				return nil, err
+			}
+			dc := &dataConn{Conn: conn, fc: fc}
+			fc.dataLock.Lock()
+			fc.data = dc
+			fc.dataLock.Unlock()
+			return dc, nil
+		}),
+	}
+	if to.Control > 0 {
+		opts = append(opts, ftp.DialWithShutTimeout(to.Control))
+	}
+	conn, err := ftp.Dial(s.addr.String(), opts...)
 	if err != nil {
+		p.dialFailures.Add(1)
+		if reportLoss {
+			p.connectionLost(err)
+		}
 		return nil, err
 	}
-	if err = conn.Login("anonymous", "anonymous"); err != nil {
+	user, password := s.credentials.login()
+	if err = conn.Login(user, password); err != nil {
+		p.dialFailures.Add(1)
+		_ = conn.Quit()
 		return nil, err
 	}
-	if p.dir != "" {
-		if err = conn.ChangeDir(p.dir); err != nil {
+	if s.dir != "" {
+		if err = conn.ChangeDir(s.dir); err != nil {
+			p.dialFailures.Add(1)
+			_ = conn.Quit()
 			return nil, err
 		}
 	}
+	fc.ServerConn = conn
 	// and add first in busyList
 	p.Lock()
 	cl := &connList{
-		conn: conn,
+		conn: fc,
 		next: p.busyList,
 	}
 	p.busyList = cl
+	restored := p.lost
+	p.lost = false
 	p.Unlock()
-	return conn, nil
+	if restored {
+		p.onEvent.emit(Event{Type: EventConnectionRestored})
+	}
+	return fc, nil
 }
 
 // get returns a connection from the pool, or creates a new connection if needed
-func (p *connPool) get() (conn *ftp.ServerConn, err error) {
+func (p *connPool) get(ctx context.Context) (conn *ftpConn, err error) {
+	ctx, span := p.getTracer().Start(ctx, "pool.acquire")
+	defer func() { endSpan(span, err) }()
 	p.Lock()
 	if idle := p.idleList; idle != nil {
 		p.idleList = idle.next
@@ -115,7 +393,7 @@ func (p *connPool) get() (conn *ftp.ServerConn, err error) {
 	}
 	p.Unlock()
 	if conn == nil {
-		conn, err = p.connect()
+		conn, err = p.connect(ctx)
 	}
 	return
 }
@@ -123,26 +401,33 @@ func (p *connPool) get() (conn *ftp.ServerConn, err error) {
 // setAddr will call Quit on all open connections, both busy and idle, change
 // the address, reconnect one connection, and put it in the idle list.
 func (p *connPool) setAddr(addr netip.AddrPort) error {
-	var idle []*ftp.ServerConn
-	var busy []*ftp.ServerConn
+	p.updateLock.Lock()
+	defer p.updateLock.Unlock()
+	var idle []*ftpConn
+	var busy []*ftpConn
 	p.Lock()
 	eq := p.addr == addr
+	changed := !eq && p.addr.IsValid()
 	if !eq {
 		idle = p.idleList.conns()
 		busy = p.busyList.conns()
 		p.idleList = nil
 		p.busyList = nil
 		p.addr = addr
+		p.gen++
 	}
 	p.Unlock()
 	if eq {
 		return nil
 	}
-	closeList(idle, true)
-	closeList(busy, true)
+	p.closeList(idle, true)
+	p.closeList(busy, true)
+	if changed {
+		p.onEvent.emit(Event{Type: EventAddressChanged, Address: addr})
+	}
 
 	// Create the first connection up front, so that a failure to connect to the server is caught early
-	conn, err := p.connect()
+	conn, err := p.connect(context.Background())
 	if err != nil {
 		return err
 	}
@@ -151,29 +436,57 @@ func (p *connPool) setAddr(addr netip.AddrPort) error {
 	return nil
 }
 
-// put returns a connection to the pool
-func (p *connPool) put(conn *ftp.ServerConn) {
-	// remove from busyList
+// startOp sets the deadline for an operation that will use the given connection, and
+// returns a function that clears it. No deadline is set unless the Operation timeout
+// is configured.
+func (p *connPool) startOp(conn *ftpConn) func() {
 	p.Lock()
-	removed := false
-	var prev *connList
-	for c := p.busyList; c != nil; c = c.next {
-		if c.conn == conn {
-			if prev == nil {
-				p.busyList = c.next
-			} else {
-				prev.next = c.next
+	timeout := p.timeouts.Operation
+	p.Unlock()
+	if timeout <= 0 {
+		return func() {}
+	}
+	conn.opDeadline.Store(time.Now().Add(timeout).UnixNano())
+	return func() { conn.opDeadline.Store(0) }
+}
+
+// put returns a connection to the pool. An aborted connection is reset in the background
+// before it is reused. A broken connection, or one that cannot be reset, is replaced by a
+// new connection that is dialed in the background.
+func (p *connPool) put(conn *ftpConn) {
+	if conn.broken.Load() {
+		p.Lock()
+		removed := p.removeBusy(conn)
+		p.Unlock()
+		if removed {
+			go p.replenish()
+		}
+		return
+	}
+	if conn.aborted.Load() {
+		go func() {
+			if !conn.reset() {
+				p.logger().Debug("unable to reset aborted connection")
+				conn.close()
 			}
-			removed = true
-			break
+			p.put(conn)
+		}()
+		return
+	}
+	p.Lock()
+	if conn.gen != p.gen {
+		// The connection was created using outdated settings
+		removed := p.removeBusy(conn)
+		p.Unlock()
+		if removed {
+			go p.closeList([]*ftpConn{conn}, true)
 		}
-		prev = c
+		return
 	}
-
 	// we only add to the idleList if it was removed from the busyList because a call
 	// to quit() might call Quit() a busy conn, which may result in a subsequent attempt
 	// to return it to the pool.
-	if removed {
+	if p.removeBusy(conn) {
 		// and add first in idleList
 		cl := &connList{
 			conn: conn,
@@ -184,11 +497,119 @@ func (p *connPool) put(conn *ftp.ServerConn) {
 	p.Unlock()
 }
 
-func closeList(conns []*ftp.ServerConn, silent bool) {
+// renew returns the given aborted connection to the pool and returns a replacement. The
+// given connection is returned if no replacement can be obtained, in which case any
+// subsequent use of it will fail.
+func (p *connPool) renew(conn *ftpConn) *ftpConn {
+	nc, err := p.get(context.Background())
+	if err != nil {
+		return conn
+	}
+	p.put(conn)
+	return nc
+}
+
+// replenish dials a new connection and adds it to the idle list, unless the pool has been closed.
+func (p *connPool) replenish() {
+	p.Lock()
+	closed := p.closed
+	p.Unlock()
+	if closed {
+		return
+	}
+	conn, err := p.connect(context.Background())
+	if err != nil {
+		p.logger().Debugf("unable to replace aborted connection: %v", err)
+		return
+	}
+	p.Lock()
+	closed = p.closed
+	p.Unlock()
+	if closed {
+		p.Lock()
+		p.removeBusy(conn)
+		p.Unlock()
+		_ = conn.Quit()
+		return
+	}
+	p.put(conn)
+}
+
+// connectionLost records that the FTP server could not be reached and emits an event unless
+// that was already known. The idle connections are closed, because they are unlikely to be
+// usable, so that the next attempt to use the pool will dial a new connection.
+func (p *connPool) connectionLost(err error) {
+	p.Lock()
+	wasLost := p.lost
+	p.lost = true
+	idle := p.idleList.conns()
+	p.idleList = nil
+	p.Unlock()
+	p.closeList(idle, true)
+	if !wasLost {
+		p.onEvent.emit(Event{Type: EventConnectionLost, Message: err.Error()})
+	}
+}
+
+// probe checks that the FTP server is reachable by sending a NOOP on an idle connection. When
+// the server is known to be unreachable, a new connection is dialed instead, so that the
+// restoration of the connection is detected.
+func (p *connPool) probe(ctx context.Context) {
+	p.Lock()
+	if p.closed {
+		p.Unlock()
+		return
+	}
+	lost := p.lost
+	var conn *ftpConn
+	if idle := p.idleList; idle != nil {
+		p.idleList = idle.next
+		idle.next = p.busyList
+		p.busyList = idle
+		conn = idle.conn
+	}
+	p.Unlock()
+	if conn == nil {
+		if lost {
+			if conn, err := p.connect(ctx); err == nil {
+				p.put(conn)
+			}
+		}
+		return
+	}
+	if err := conn.NoOp(); err != nil {
+		// The connection is broken and must be replaced.
+		conn.close()
+		p.connectionLost(err)
+	}
+	p.put(conn)
+}
+
+// removeBusy removes the given connection from the busy list and returns true if it was found. The
+// pool must be locked when this method is called.
+func (p *connPool) removeBusy(conn *ftpConn) bool {
+	removed := false
+	var prev *connList
+	for c := p.busyList; c != nil; c = c.next {
+		if c.conn == conn {
+			if prev == nil {
+				p.busyList = c.next
+			} else {
+				prev.next = c.next
+			}
+			removed = true
+			break
+		}
+		prev = c
+	}
+	return removed
+}
+
+func (p *connPool) closeList(conns []*ftpConn, silent bool) {
 	for _, c := range conns {
 		if err := c.Quit(); err != nil && !silent {
-			if !strings.Contains(err.Error(), "use of closed") {
-				log.Errorf("quit failed: %v", err)
+			if !errors.Is(err, net.ErrClosed) {
+				p.logger().Errorf("quit failed: %v", err)
 			}
 		}
 	}
@@ -201,9 +622,10 @@ func (p *connPool) quit() {
 	busy := p.idleList.conns()
 	p.idleList = nil
 	p.busyList = nil
+	p.closed = true
 	p.Unlock()
-	closeList(idle, false)
-	closeList(busy, false)
+	p.closeList(idle, false)
+	p.closeList(busy, false)
 }
 
 // tidy will attempt to shrink the number of open connections to two, but since it
@@ -212,8 +634,8 @@ func (p *connPool) quit() {
 func (p *connPool) tidy() {
 	p.Lock()
 	idle := p.idleList.conns()
-	idleCount := 64 - p.busyList.size()
-	var cl []*ftp.ServerConn
+	idleCount := p.limits.maxIdle() - p.busyList.size()
+	var cl []*ftpConn
 	if idleCount > 0 && len(idle) > idleCount {
 		cl = idle[idleCount:]
 		p.idleList = nil
@@ -222,5 +644,5 @@ func (p *connPool) tidy() {
 		}
 	}
 	p.Unlock()
-	closeList(cl, false)
+	p.closeList(cl, false)
 }
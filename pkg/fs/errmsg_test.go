@@ -1,6 +1,8 @@
 package fs
 
-// error texts that are found in reported errors using strings.Contain(err.Error(), errXXX)
+import "strings"
+
+// error texts that are found in errors reported by the mounted file system
 const (
 	errBrokenPipe             = "broken pipe"
 	errClosed                 = "use of closed"
@@ -11,3 +13,12 @@ const (
 	errIsDirectory            = "is a directory"
 	errUnexpectedNetworkError = "unexpected network error"
 )
+
+func containsAny(str string, ss ...string) bool {
+	for _, s := range ss {
+		if strings.Contains(str, s) {
+			return true
+		}
+	}
+	return false
+}
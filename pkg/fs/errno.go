@@ -0,0 +1,266 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/textproto"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+// ErrorRule maps an FTP reply to an errno. Rules are used to override the default mapping
+// for servers that use reply codes or messages in unusual ways.
+type ErrorRule struct {
+	// Code is the FTP reply code that the rule applies to.
+	Code int
+
+	// Message is an optional regular expression that the reply message must match for the
+	// rule to apply. The match is case-insensitive.
+	Message string
+
+	// Errno is the positive error number, e.g. fuse.EACCES, that a matching reply maps to.
+	Errno int
+}
+
+// WithErrorRules adds rules that take precedence over the default mapping from FTP replies
+// to errno values. The rules are evaluated in order, and the first matching rule wins.
+func WithErrorRules(rules ...ErrorRule) Option {
+	return func(f *fuseImpl) {
+		f.errorRules = append(f.errorRules, rules...)
+	}
+}
+
+// errorRule is the compiled form of an ErrorRule
+type errorRule struct {
+	code    int
+	message *regexp.Regexp
+	errno   int
+}
+
+func compileErrorRules(rules []ErrorRule) ([]errorRule, error) {
+	crs := make([]errorRule, len(rules))
+	for i, r := range rules {
+		if r.Code < 100 || r.Code > 999 {
+			return nil, fmt.Errorf("invalid FTP reply code %d in error rule", r.Code)
+		}
+		if r.Errno <= 0 {
+			return nil, fmt.Errorf("invalid errno %d in error rule for reply code %d", r.Errno, r.Code)
+		}
+		crs[i] = errorRule{code: r.Code, errno: r.Errno}
+		if r.Message != "" {
+			rx, err := regexp.Compile("(?i)" + r.Message)
+			if err != nil {
+				return nil, fmt.Errorf("invalid message pattern in error rule for reply code %d: %w", r.Code, err)
+			}
+			crs[i].message = rx
+		}
+	}
+	return crs, nil
+}
+
+func mustCompileErrorRules(rules ...ErrorRule) []errorRule {
+	crs, err := compileErrorRules(rules)
+	if err != nil {
+		panic(err)
+	}
+	return crs
+}
+
+// messageRules refine the replyErrnos mapping based on the reply message. Servers often use
+// the same reply code for several conditions, and report the underlying error (typically
+// the text of an OS error) in the message.
+var messageRules = mustCompileErrorRules(
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `permission denied|access (is )?denied|not permitted|forbidden`, Errno: fuse.EACCES},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `file exists|already exists`, Errno: fuse.EEXIST},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `not empty`, Errno: fuse.ENOTEMPTY},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `not a directory`, Errno: fuse.ENOTDIR},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `is a directory`, Errno: fuse.EISDIR},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `read-only file system`, Errno: fuse.EROFS},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `no space left`, Errno: fuse.ENOSPC},
+	ErrorRule{Code: ftp.StatusFileUnavailable, Message: `name too long`, Errno: fuse.ENAMETOOLONG},
+	ErrorRule{Code: ftp.StatusFileActionIgnored, Message: `permission denied|access (is )?denied|not permitted`, Errno: fuse.EACCES},
+	ErrorRule{Code: ftp.StatusFileActionIgnored, Message: `no such file|not found|cannot find`, Errno: fuse.ENOENT},
+	ErrorRule{Code: ftp.StatusActionAborted, Message: `no space left`, Errno: fuse.ENOSPC},
+	ErrorRule{Code: ftp.StatusBadFileName, Message: `file exists|already exists`, Errno: fuse.EEXIST},
+	ErrorRule{Code: ftp.StatusBadFileName, Message: `permission denied|access (is )?denied|not permitted`, Errno: fuse.EACCES},
+	ErrorRule{Code: ftp.StatusBadFileName, Message: `name too long`, Errno: fuse.ENAMETOOLONG},
+)
+
+// replyErrnos is the default mapping from the reply codes of RFC 959, RFC 2228, RFC 2428,
+// and RFC 3659 to errno values. A reply is only mapped when it was returned as an error, so
+// a positive completion reply is unexpected, and maps to EIO unless the transfer was cut
+// short. Preliminary and intermediate replies are unexpected too, and map to EPROTO.
+var replyErrnos = map[int]int{
+	ftp.StatusInitiating:    fuse.EPROTO,
+	ftp.StatusRestartMarker: fuse.EPROTO,
+	ftp.StatusReadyMinute:   fuse.EAGAIN,
+	ftp.StatusAlreadyOpen:   fuse.EPROTO,
+	ftp.StatusAboutToSend:   fuse.EPROTO,
+
+	ftp.StatusClosingDataConnection: fuse.ECONNABORTED, // reported as an error when the transfer was cut short
+
+	ftp.StatusUserOK:             fuse.EACCES,
+	ftp.StatusLoginNeedAccount:   fuse.EACCES,
+	334:                          fuse.EACCES, // security mechanism accepted, ADAT required (RFC 2228)
+	335:                          fuse.EACCES, // ADAT accepted, more data required (RFC 2228)
+	336:                          fuse.EACCES, // username okay, need password, challenge is ... (RFC 2228)
+	ftp.StatusRequestFilePending: fuse.EPROTO,
+
+	ftp.StatusNotAvailable:             fuse.EADDRNOTAVAIL,
+	ftp.StatusCanNotOpenDataConnection: fuse.ECONNREFUSED,
+	ftp.StatusTransfertAborted:         fuse.ECONNABORTED,
+	ftp.StatusInvalidCredentials:       fuse.EACCES,
+	431:                                fuse.EACCES, // need some unavailable resource to process security (RFC 2228)
+	ftp.StatusHostUnavailable:          fuse.EHOSTUNREACH,
+	ftp.StatusFileActionIgnored:        fuse.EBUSY,
+	ftp.StatusActionAborted:            fuse.EIO,
+	ftp.Status452:                      fuse.ENOSPC,
+
+	ftp.StatusBadCommand:              fuse.ENOSYS,
+	ftp.StatusBadArguments:            fuse.EINVAL,
+	ftp.StatusNotImplemented:          fuse.ENOSYS,
+	ftp.StatusBadSequence:             fuse.EPROTO,
+	ftp.StatusNotImplementedParameter: fuse.ENOTSUP,
+	522:                               fuse.EAFNOSUPPORT, // network protocol not supported (RFC 2428)
+	ftp.StatusNotLoggedIn:             fuse.EACCES,
+	ftp.StatusStorNeedAccount:         fuse.EACCES,
+	533:                               fuse.EACCES,  // command protection level denied for policy reasons (RFC 2228)
+	534:                               fuse.EACCES,  // request denied for policy reasons (RFC 2228)
+	535:                               fuse.EACCES,  // failed security check (RFC 2228)
+	536:                               fuse.ENOTSUP, // requested PROT level not supported (RFC 2228)
+	537:                               fuse.ENOTSUP, // command protection level not supported (RFC 2228)
+	ftp.StatusFileUnavailable:         fuse.ENOENT,  // mostly a missing file; messageRules and overrides refine it
+	ftp.StatusPageTypeUnknown:         fuse.EINVAL,
+	ftp.StatusExceededStorage:         fuse.ENOSPC,
+	ftp.StatusBadFileName:             fuse.EINVAL,
+
+	631: fuse.EPROTO, // integrity protected reply (RFC 2228)
+	632: fuse.EPROTO, // confidentiality and integrity protected reply (RFC 2228)
+	633: fuse.EPROTO, // confidentiality protected reply (RFC 2228)
+}
+
+// errNotDir is returned when a directory operation is attempted on something else.
+var errNotDir = errors.New("not a directory")
+
+// errnoErrors maps errors that are found using errors.Is to errno values. The table is
+// consulted after the FTP reply mapping. The syscall errors of the current platform are
+// appended in errno_unix.go and errno_windows.go.
+var errnoErrors = append([]struct {
+	err   error
+	errno int
+}{
+	{errInterrupted, fuse.EINTR},
+	{errNotDir, fuse.ENOTDIR},
+	{os.ErrDeadlineExceeded, fuse.ETIMEDOUT},
+	{context.DeadlineExceeded, fuse.ETIMEDOUT},
+	{context.Canceled, fuse.ECANCELED},
+	{net.ErrClosed, fuse.ECONNABORTED},
+	{io.ErrClosedPipe, fuse.EPIPE},
+	{io.ErrUnexpectedEOF, fuse.EIO},
+	{io.EOF, fuse.EIO},
+	{fs.ErrNotExist, fuse.ENOENT},
+	{fs.ErrExist, fuse.EEXIST},
+	{fs.ErrPermission, fuse.EACCES},
+	{fs.ErrInvalid, fuse.EINVAL},
+}, syscallErrnos...)
+
+// errnoOf returns the errno that corresponds to the given error, and false if no
+// mapping was found.
+func (f *fuseImpl) errnoOf(err error) (int, bool) {
+	var tpe *textproto.Error
+	if errors.As(err, &tpe) {
+		return f.replyErrno(tpe.Code, tpe.Msg)
+	}
+	for _, ee := range errnoErrors {
+		if errors.Is(err, ee.err) {
+			return ee.errno, true
+		}
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return fuse.ETIMEDOUT, true
+	}
+	return 0, false
+}
+
+// replyErrno maps an FTP reply to an errno. The rules given to the client take precedence
+// over the message rules, which in turn take precedence over the mapping of reply codes. A
+// code that is unknown is mapped using its first digit.
+func (f *fuseImpl) replyErrno(code int, msg string) (int, bool) {
+	for _, rules := range [][]errorRule{f.errorMap, messageRules} {
+		for _, r := range rules {
+			if r.code == code && (r.message == nil || r.message.MatchString(msg)) {
+				return r.errno, true
+			}
+		}
+	}
+	if errno, ok := replyErrnos[code]; ok {
+		return errno, true
+	}
+	switch code / 100 {
+	case 2:
+		return fuse.EIO, true
+	case 1, 3, 6:
+		return fuse.EPROTO, true
+	}
+	return fuse.EIO, false
+}
+
+var (
+	errnoNamesOnce sync.Once
+	errnoNames     map[string]int
+)
+
+// ParseErrno returns the positive errno value for the given name, e.g. "EACCES", or
+// decimal number.
+func ParseErrno(name string) (int, error) {
+	if n, err := strconv.Atoi(name); err == nil {
+		if n <= 0 {
+			return 0, fmt.Errorf("invalid errno %d", n)
+		}
+		return n, nil
+	}
+	errnoNamesOnce.Do(func() {
+		// The fuse package knows the names of all errno values that it defines.
+		errnoNames = make(map[string]int)
+		for e := 1; e < 4096; e++ {
+			if s, ok := strings.CutPrefix(fuse.Error(-e).Error(), "-fuse."); ok {
+				errnoNames[s] = e
+			}
+		}
+	})
+	if e, ok := errnoNames[strings.ToUpper(name)]; ok {
+		return e, nil
+	}
+	return 0, fmt.Errorf("unknown errno %q", name)
+}
+
+// ErrnoName returns the name of the given positive errno value, e.g. "ENOENT".
+func ErrnoName(errno int) string {
+	if s, ok := strings.CutPrefix(fuse.Error(-errno).Error(), "-fuse."); ok {
+		return s
+	}
+	return strconv.Itoa(errno)
+}
+
+func (f *fuseImpl) errToFuseErr(err error) int {
+	if err == nil {
+		return 0
+	}
+	errno, ok := f.errnoOf(err)
+	if !ok {
+		f.logger().Errorf("no errno mapping for %T %v, using EIO", err, err)
+		errno = fuse.EIO
+	}
+	return -errno
+}
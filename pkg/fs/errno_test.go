@@ -0,0 +1,97 @@
+package fs
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"net/textproto"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestErrToFuseErr(t *testing.T) {
+	reply := func(code int, msg string) error {
+		return &textproto.Error{Code: code, Msg: msg}
+	}
+	f := &fuseImpl{}
+	var err error
+	f.errorMap, err = compileErrorRules([]ErrorRule{
+		{Code: ftp.StatusFileUnavailable, Message: "quota", Errno: fuse.ENOSPC},
+		{Code: ftp.StatusBadCommand, Errno: fuse.EINVAL},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		err   error
+		errno int
+	}{
+		{"nil", nil, 0},
+		{"command OK", reply(ftp.StatusCommandOK, "ok"), -fuse.EIO},
+		{"ADAT accepted", reply(235, "ADAT=xyz"), -fuse.EIO},
+		{"unknown 2xx", reply(299, "fine"), -fuse.EIO},
+		{"transfer cut short", reply(ftp.StatusClosingDataConnection, "closing"), -fuse.ECONNABORTED},
+		{"not found", reply(ftp.StatusFileUnavailable, "open /x: no such file or directory"), -fuse.ENOENT},
+		{"generic 550", reply(ftp.StatusFileUnavailable, "Requested action not taken. File unavailable."), -fuse.ENOENT},
+		{"bare 550", reply(ftp.StatusFileUnavailable, ""), -fuse.ENOENT},
+		{"permission denied", reply(ftp.StatusFileUnavailable, "open /x: permission denied"), -fuse.EACCES},
+		{"permission denied wrapped", &fs.PathError{Op: "rmdir", Path: "/x", Err: reply(ftp.StatusFileUnavailable, "Permission denied")}, -fuse.EACCES},
+		{"not empty", reply(ftp.StatusFileUnavailable, "remove /x: directory not empty"), -fuse.ENOTEMPTY},
+		{"exists", reply(ftp.StatusFileUnavailable, "mkdir /x: file exists"), -fuse.EEXIST},
+		{"storage", reply(ftp.Status452, "disk full"), -fuse.ENOSPC},
+		{"not logged in", reply(ftp.StatusNotLoggedIn, "login first"), -fuse.EACCES},
+		{"unknown 4xx", reply(499, "strange"), -fuse.EIO},
+		{"override with message", reply(ftp.StatusFileUnavailable, "quota exceeded"), -fuse.ENOSPC},
+		{"override code", reply(ftp.StatusBadCommand, "what?"), -fuse.EINVAL},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connect: %w", syscall.ECONNREFUSED)}, -fuse.ECONNREFUSED},
+		{"closed", fmt.Errorf("read: %w", net.ErrClosed), -fuse.ECONNABORTED},
+		{"interrupted", errInterrupted, -fuse.EINTR},
+		{"not a directory", &fs.PathError{Op: "rmdir", Path: "/x", Err: errNotDir}, -fuse.ENOTDIR},
+		{"unknown", fmt.Errorf("something odd"), -fuse.EIO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.errno, f.errToFuseErr(tt.err))
+		})
+	}
+
+	// A server that uses a generic 550 for denied access needs an override
+	f.errorMap, err = compileErrorRules([]ErrorRule{{Code: ftp.StatusFileUnavailable, Errno: fuse.EACCES}})
+	require.NoError(t, err)
+	assert.Equal(t, -fuse.EACCES, f.errToFuseErr(reply(ftp.StatusFileUnavailable, "Requested action not taken. File unavailable.")))
+	assert.Equal(t, -fuse.ENOTDIR, f.errToFuseErr(&fs.PathError{Op: "rmdir", Path: "/x", Err: errNotDir}))
+
+	// Mapping an error has no side effects. Lost connections are detected by the pool.
+	f.pool.onEvent = func(e Event) { t.Errorf("unexpected event %s", e.Type) }
+	assert.Equal(t, -fuse.ETIMEDOUT, f.errToFuseErr(&net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded}))
+	assert.Empty(t, f.Stats().ReplyCodes)
+}
+
+func TestParseErrno(t *testing.T) {
+	errno, err := ParseErrno("EACCES")
+	require.NoError(t, err)
+	assert.Equal(t, fuse.EACCES, errno)
+
+	errno, err = ParseErrno("enoent")
+	require.NoError(t, err)
+	assert.Equal(t, fuse.ENOENT, errno)
+
+	errno, err = ParseErrno("13")
+	require.NoError(t, err)
+	assert.Equal(t, 13, errno)
+
+	_, err = ParseErrno("ENOTANERRNO")
+	assert.Error(t, err)
+
+	// An error must never map to success
+	_, err = ParseErrno("0")
+	assert.Error(t, err)
+	_, err = compileErrorRules([]ErrorRule{{Code: ftp.StatusFileUnavailable, Errno: 0}})
+	assert.Error(t, err)
+}
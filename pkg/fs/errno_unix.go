@@ -0,0 +1,25 @@
+//go:build !windows
+
+package fs
+
+import (
+	"syscall"
+
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+var syscallErrnos = []struct {
+	err   error
+	errno int
+}{
+	{syscall.ECONNREFUSED, fuse.ECONNREFUSED},
+	{syscall.ECONNRESET, fuse.ECONNRESET},
+	{syscall.ECONNABORTED, fuse.ECONNABORTED},
+	{syscall.EPIPE, fuse.EPIPE},
+	{syscall.ETIMEDOUT, fuse.ETIMEDOUT},
+	{syscall.EHOSTUNREACH, fuse.EHOSTUNREACH},
+	{syscall.ENETUNREACH, fuse.ENETUNREACH},
+	{syscall.ENETDOWN, fuse.ENETDOWN},
+	{syscall.EADDRNOTAVAIL, fuse.EADDRNOTAVAIL},
+	{syscall.ENOTCONN, fuse.ENOTCONN},
+}
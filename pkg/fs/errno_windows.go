@@ -0,0 +1,22 @@
+package fs
+
+import (
+	"github.com/winfsp/cgofuse/fuse"
+	"golang.org/x/sys/windows"
+)
+
+var syscallErrnos = []struct {
+	err   error
+	errno int
+}{
+	{windows.WSAECONNREFUSED, fuse.ECONNREFUSED},
+	{windows.WSAECONNRESET, fuse.ECONNRESET},
+	{windows.WSAECONNABORTED, fuse.ECONNABORTED},
+	{windows.WSAETIMEDOUT, fuse.ETIMEDOUT},
+	{windows.WSAEHOSTUNREACH, fuse.EHOSTUNREACH},
+	{windows.WSAENETUNREACH, fuse.ENETUNREACH},
+	{windows.WSAENETDOWN, fuse.ENETDOWN},
+	{windows.WSAEADDRNOTAVAIL, fuse.EADDRNOTAVAIL},
+	{windows.WSAENOTCONN, fuse.ENOTCONN},
+	{windows.ERROR_BROKEN_PIPE, fuse.EPIPE},
+}
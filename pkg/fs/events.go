@@ -0,0 +1,107 @@
+package fs
+
+import (
+	"net/netip"
+	"time"
+)
+
+// EventType is the type of an Event.
+type EventType int
+
+const (
+	// EventMounted is emitted by the FuseHost when the file system has been mounted.
+	EventMounted EventType = iota + 1
+
+	// EventUnmounted is emitted by the FuseHost when the file system is no longer mounted.
+	EventUnmounted
+
+	// EventConnectionLost is emitted when the FTP server can no longer be reached.
+	EventConnectionLost
+
+	// EventConnectionRestored is emitted when the FTP server can be reached again after
+	// the connection was lost.
+	EventConnectionRestored
+
+	// EventAddressChanged is emitted when the address of the FTP server has changed.
+	EventAddressChanged
+
+	// EventTransferError is emitted when a RETR or STOR of a file fails.
+	EventTransferError
+
+	// EventCacheEviction is emitted when cached information about a path is discarded.
+	EventCacheEviction
+
+	// EventDead is emitted by the FuseHost when the file system was unmounted by something
+	// other than a call to Stop, e.g. by "fusermount -u" or because the kernel aborted the
+	// FUSE connection.
+	EventDead
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventMounted:
+		return "mounted"
+	case EventUnmounted:
+		return "unmounted"
+	case EventConnectionLost:
+		return "connection lost"
+	case EventConnectionRestored:
+		return "connection restored"
+	case EventAddressChanged:
+		return "address changed"
+	case EventTransferError:
+		return "transfer error"
+	case EventCacheEviction:
+		return "cache eviction"
+	case EventDead:
+		return "dead"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes something that happened to a mount.
+type Event struct {
+	Type EventType
+	Time time.Time
+
+	// Path is the path affected by a transfer error or cache eviction.
+	Path string
+
+	// Address is the new address of the FTP server when the address changed.
+	Address netip.AddrPort
+
+	// Message contains details, such as the text of an error.
+	Message string
+}
+
+// EventHandler is called for each event that a mount emits. It is called synchronously
+// from the emitting goroutine and must not block.
+type EventHandler func(Event)
+
+// WithEventHandler sets the function that receives the events of the FTP client and of
+// the FuseHost that mounts it.
+func WithEventHandler(h EventHandler) Option {
+	return func(f *fuseImpl) {
+		f.onEvent = h
+	}
+}
+
+// eventEmitter is implemented by file systems that emit events. The FuseHost uses it to
+// emit events through the same handler as the file system that it mounts.
+type eventEmitter interface {
+	emit(e Event)
+}
+
+func (h EventHandler) emit(e Event) {
+	if h != nil {
+		if e.Time.IsZero() {
+			e.Time = time.Now()
+		}
+		h(e)
+	}
+}
+
+func (f *fuseImpl) emit(e Event) {
+	f.onEvent.emit(e)
+}
@@ -0,0 +1,85 @@
+package fs
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolEvents(t *testing.T) {
+	addr := startHangingServer(t)
+
+	// obtain an address where nothing listens
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	deadAddr := netip.MustParseAddrPort(l.Addr().String())
+	require.NoError(t, l.Close())
+
+	var mu sync.Mutex
+	var events []Event
+	p := &connPool{
+		timeouts: Timeouts{}.withDefaults(5 * time.Second),
+		dialer:   &net.Dialer{},
+		onEvent: func(e Event) {
+			mu.Lock()
+			events = append(events, e)
+			mu.Unlock()
+		},
+	}
+	t.Cleanup(p.quit)
+	types := func() []EventType {
+		mu.Lock()
+		defer mu.Unlock()
+		ts := make([]EventType, len(events))
+		for i, e := range events {
+			ts[i] = e.Type
+		}
+		return ts
+	}
+
+	require.NoError(t, p.setAddr(addr))
+	assert.Empty(t, types())
+
+	require.Error(t, p.setAddr(deadAddr))
+	assert.Equal(t, []EventType{EventAddressChanged, EventConnectionLost}, types())
+
+	// further failures are not reported
+	_, err = p.get(context.Background())
+	require.Error(t, err)
+	assert.Len(t, types(), 2)
+
+	require.NoError(t, p.setAddr(addr))
+	assert.Equal(t, []EventType{EventAddressChanged, EventConnectionLost, EventAddressChanged, EventConnectionRestored}, types())
+	mu.Lock()
+	assert.Equal(t, addr, events[2].Address)
+	mu.Unlock()
+}
+
+func TestProbe(t *testing.T) {
+	addr := startHangingServer(t)
+	evCh := make(chan EventType, 10)
+	p := &connPool{
+		timeouts: Timeouts{}.withDefaults(200 * time.Millisecond),
+		dialer:   &net.Dialer{},
+		onEvent:  func(e Event) { evCh <- e.Type },
+	}
+	require.NoError(t, p.setAddr(addr))
+	t.Cleanup(p.quit)
+
+	// The hanging server never replies to NOOP, so the probe fails and the connection is replaced.
+	p.probe(context.Background())
+	for _, et := range []EventType{EventConnectionLost, EventConnectionRestored} {
+		select {
+		case e := <-evCh:
+			assert.Equal(t, et, e)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no %s event", et)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package fs
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+)
+
+// entry is an ftp.Entry together with the facts that the FTP server reported for it. The
+// ftp package discards facts such as UNIX.owner, so they are parsed from the raw listing.
+// Fact names are in lower case. The facts are nil when the server reported none.
+type entry struct {
+	ftp.Entry
+	facts map[string]string
+}
+
+// stat performs an MLST for the given path.
+func (c *ftpConn) stat(path string) (*entry, error) {
+	var e *ftp.Entry
+	data, err := capture(&c.controlTap, func() (err error) {
+		e, err = c.GetEntry(path)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ne := &entry{Entry: *e}
+	// The facts are on the line of the reply that starts with a space.
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, " ") {
+			if _, facts, ok := parseFactsLine(strings.TrimSpace(line)); ok {
+				ne.facts = facts
+				break
+			}
+		}
+	}
+	return ne, nil
+}
+
+// list performs an MLSD, or a LIST when the server doesn't support MLSD, for the given path, and
+// calls fn for each entry. The entries of an MLSD are parsed from the data connection and passed
+// to fn as their lines arrive, so that large directories can be served before the listing ends.
+// The entries of a LIST are passed to fn once it has ended. The ftp package has no way to send an
+// MLSD other than List, which collects and parses the entries too, so a listing still costs the
+// memory and parsing of its entries in that package until it ends.
+func (c *ftpConn) list(path string, fn func(*entry)) error {
+	if !c.IsTimePreciseInList() {
+		var es []*ftp.Entry
+		data, err := capture(&c.dataTap, func() (err error) {
+			es, err = c.List(path)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		facts := parseListing(data)
+		for _, e := range es {
+			fn(&entry{Entry: *e, facts: facts[e.Name]})
+		}
+		return nil
+	}
+
+	var pending []byte
+	emit := func(line []byte) {
+		if e, ok := parseMLSDLine(string(bytes.TrimRight(line, "\r"))); ok {
+			fn(e)
+		}
+	}
+	err := withTap(&c.dataTap, func(b []byte) {
+		pending = append(pending, b...)
+		start := 0
+		for {
+			i := bytes.IndexByte(pending[start:], '\n')
+			if i < 0 {
+				break
+			}
+			emit(pending[start : start+i])
+			start += i + 1
+		}
+		pending = append(pending[:0], pending[start:]...)
+	}, func() error {
+		// The entries that the ftp package collects are dropped as soon as the listing ends.
+		_, err := c.List(path)
+		return err
+	})
+	if err == nil && len(pending) > 0 {
+		emit(pending)
+	}
+	return err
+}
+
+// capture calls fn and returns the data that was read from the connections that use the given
+// tap in the meantime.
+func capture(tap *atomic.Pointer[tapFunc], fn func() error) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	err := withTap(tap, func(b []byte) { buf.Write(b) }, fn)
+	return buf.Bytes(), err
+}
+
+// withTap calls fn while the given tap is set to t.
+func withTap(tap *atomic.Pointer[tapFunc], t tapFunc, fn func() error) error {
+	tap.Store(&t)
+	defer tap.Store(nil)
+	return fn()
+}
+
+// parseMLSDLine parses a line of an MLSD response into an entry. Lines that can't be parsed, and
+// the lines for the directory itself and its parent, are rejected.
+func parseMLSDLine(line string) (*entry, bool) {
+	name, facts, ok := parseFactsLine(line)
+	if !ok {
+		return nil, false
+	}
+	e := &entry{Entry: ftp.Entry{Name: name}, facts: facts}
+	switch facts["type"] {
+	case "cdir", "pdir":
+		return nil, false
+	case "dir":
+		e.Type = ftp.EntryTypeFolder
+	}
+	if v, ok := facts["size"]; ok {
+		sz, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return nil, false
+		}
+		e.Size = sz
+	}
+	if v, ok := facts["modify"]; ok {
+		// Same layout and location as the ftp package. A fraction of a second is accepted too.
+		t, err := time.ParseInLocation("20060102150405", v, time.UTC)
+		if err != nil {
+			return nil, false
+		}
+		e.Time = t
+	}
+	return e, true
+}
+
+// parseListing parses the lines of an MLSD or LIST response, and returns the facts of each name.
+func parseListing(data []byte) map[string]map[string]string {
+	m := make(map[string]map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		name, facts, ok := parseFactsLine(line)
+		if !ok {
+			name, facts, ok = parseLsLine(line)
+		}
+		if ok {
+			m[name] = facts
+		}
+	}
+	return m
+}
+
+// parseFactsLine parses a line of an MLSD or MLST response, which consists of "name=value;" facts
+// followed by a space and the name of the entry.
+func parseFactsLine(line string) (string, map[string]string, bool) {
+	fl, name, ok := strings.Cut(line, " ")
+	if !ok || name == "" {
+		return "", nil, false
+	}
+	facts := make(map[string]string)
+	for _, f := range strings.Split(fl, ";") {
+		if f == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(f, "=")
+		if !ok {
+			return "", nil, false
+		}
+		facts[strings.ToLower(k)] = v
+	}
+	return name, facts, true
+}
+
+// parseLsLine parses a line of a LIST response in the format used by "ls -l", and returns the
+// owner, group, and mode of the entry as the facts UNIX.owner, UNIX.group, and UNIX.mode.
+func parseLsLine(line string) (string, map[string]string, bool) {
+	// Skip the permissions, link count, owner, group, size, month, day, and time or year.
+	fields := make([]string, 0, 8)
+	rest := line
+	for len(fields) < 8 {
+		rest = strings.TrimLeft(rest, " ")
+		f, r, ok := strings.Cut(rest, " ")
+		if !ok {
+			return "", nil, false
+		}
+		fields = append(fields, f)
+		rest = r
+	}
+	name := strings.TrimLeft(rest, " ")
+	perms := fields[0]
+	if len(perms) < 10 || !strings.ContainsRune("-dlbcps", rune(perms[0])) || name == "" {
+		return "", nil, false
+	}
+	if perms[0] == 'l' {
+		name, _, _ = strings.Cut(name, " -> ")
+	}
+	mode := uint32(0)
+	for i, c := range perms[1:10] {
+		if c != '-' && c != 'S' && c != 'T' {
+			mode |= 1 << (8 - i)
+		}
+	}
+	return name, map[string]string{
+		"unix.owner": fields[2],
+		"unix.group": fields[3],
+		"unix.mode":  "0" + strconv.FormatUint(uint64(mode), 8),
+	}, true
+}
@@ -10,16 +10,19 @@ import (
 	"math"
 	"net/netip"
 	"net/textproto"
-	"os"
+	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jlaffaye/ftp"
 	log "github.com/sirupsen/logrus"
 	"github.com/winfsp/cgofuse/fuse"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/time/rate"
 )
 
 // fuseImpl implements the fuse.FileSystemInterface. The official documentation for the API
@@ -30,12 +33,36 @@ type fuseImpl struct {
 	// connPool is the pool of control connections to the remote FTP server.
 	pool connPool
 
+	// ctx is cancelled when the client is destroyed
+	ctx context.Context
+
 	// cancel the GC loop
 	cancel context.CancelFunc
 
-	// Mutex protects nextHandle, current, and shuttingDown
+	// mounted is true between the calls to Init and Destroy. The FUSE context is only
+	// available while mounted.
+	mounted atomic.Bool
+
+	// upload and download limits the bandwidth used by the mount
+	upload   *rate.Limiter
+	download *rate.Limiter
+
+	// Mutex protects nextHandle, current, shuttingDown, and rateLimits
 	sync.RWMutex
 
+	// rateLimits are the currently active bandwidth limits
+	rateLimits RateLimits
+
+	// onEvent receives the events emitted by the client
+	onEvent EventHandler
+
+	// stats collects operation counters and latencies
+	stats stats
+
+	// errorRules are the rules given using WithErrorRules, and errorMap is their compiled form
+	errorRules []ErrorRule
+	errorMap   []errorRule
+
 	// Next file handle. File handles are opaque to FUSE, and much faster to use than
 	// the full path
 	nextHandle uint64
@@ -45,6 +72,38 @@ type fuseImpl struct {
 
 	// shuttingDown prevent that new handles are added
 	shuttingDown bool
+
+	// destroyed is set by the first call to Destroy
+	destroyed atomic.Bool
+
+	// log is the logger of the client. The standard logger is used when it is nil.
+	log *log.Entry
+
+	// readOnly is set when modifications are rejected with EROFS, and mountedReadOnly is set
+	// when the file system was mounted read-only.
+	readOnly        atomic.Bool
+	mountedReadOnly atomic.Bool
+
+	// identity controls the owner and permissions that are reported.
+	identity Identity
+
+	// accessChecks is set when the permissions of the caller are checked by the file system
+	// instead of the kernel.
+	accessChecks bool
+
+	// inodes assigns the inode numbers that are reported.
+	inodes inodeTable
+
+	// attrs holds the entries that Readdir has returned recently.
+	attrs attrCache
+
+	// userCredentials are the credentials used for calls made by specific local users, and
+	// userPools are the connection pools that have been created for those users. The
+	// userPoolDials are closed when the pools that are being created for users are done.
+	userCredentials map[uint32]Credentials
+	userPoolsLock   sync.Mutex
+	userPools       map[uint32]*connPool
+	userPoolDials   map[uint32]chan struct{}
 }
 
 // info holds information about file or directory that has been obtained from
@@ -65,7 +124,14 @@ type info struct {
 
 	// conn is the dedicated connection that is used during read/write operations with
 	// this handle
-	conn *ftp.ServerConn
+	conn *ftpConn
+
+	// pool is the pool of the user that opened the handle. Its connections are taken from,
+	// and returned to, this pool.
+	pool *connPool
+
+	// wconn is the connection used by the STOR that consumes the pipe during Write
+	wconn *ftpConn
 
 	// rr and of is used when reading data from a remote file
 	rr *ftp.Response
@@ -77,17 +143,27 @@ type info struct {
 	wof uint64
 
 	// The Entry from the ftp server with estimated size based on initial size and write/truncate operations
-	entry ftp.Entry
+	entry entry
 
 	// The writer is the writer side of an io.Pipe() used when writing data to a remote file.
 	writer io.WriteCloser
 
 	// 1 is added to this wg when the reader/writer pipe is created. Wait for it when closing the writer.
 	wg sync.WaitGroup
+
+	// upload and download limits the bandwidth used by this handle
+	upload   *rate.Limiter
+	download *rate.Limiter
+
+	// listing is the listing of a directory that is read using this handle
+	listing *dirListing
 }
 
 // close this handle and free up any resources that it holds.
 func (i *info) close() {
+	if i.listing != nil {
+		i.listing.stop()
+	}
 	if i.rr != nil {
 		_ = i.rr.Close()
 	}
@@ -95,10 +171,24 @@ func (i *info) close() {
 		_ = i.writer.Close()
 	}
 	i.wg.Wait()
+	if i.writer != nil {
+		// The size and time of the file have changed
+		i.attrs.remove(i.path)
+	}
+}
+
+// renewConn replaces the connection of this handle if it has been aborted.
+func (i *info) renewConn() {
+	if i.conn.aborted.Load() {
+		i.conn = i.pool.renew(i.conn)
+	}
 }
 
 const stalePeriod = time.Second // Fuse default cache time
 
+// probeInterval is the interval between checks that the FTP server is reachable.
+const probeInterval = 10 * time.Second
+
 type FTPClient interface {
 	fuse.FileSystemInterface
 
@@ -106,58 +196,115 @@ type FTPClient interface {
 	// The method is intended to be used when a FUSE mount must survive a change of
 	// FTP server address.
 	SetAddress(addr netip.AddrPort) error
+
+	// SetRateLimits changes the bandwidth limits of the mount and its open file handles.
+	SetRateLimits(rl RateLimits)
+
+	// SetTimeouts changes the timeouts. Open connections are replaced once they are idle.
+	SetTimeouts(readTimeout time.Duration, t Timeouts) error
+
+	// SetDirectory changes the directory on the FTP server that is mounted, replaces open
+	// connections once they are idle, and evicts all open file handles.
+	SetDirectory(dir string) error
+
+	// SetCredentials changes the credentials used when logging in to the FTP server. Open
+	// connections are replaced once they are idle.
+	SetCredentials(c Credentials) error
+
+	// SetPoolLimits changes the limits of the connection pool.
+	SetPoolLimits(l PoolLimits)
+
+	// SetAttrCacheTTL changes the time that the attributes returned by Readdir are used to
+	// answer lookups.
+	SetAttrCacheTTL(ttl time.Duration)
+
+	// SetReadOnly changes whether modifications are rejected with EROFS. A file system that
+	// was mounted read-only cannot be made writable without remounting it.
+	SetReadOnly(ro bool) error
+
+	// Stats returns a snapshot of the statistics of the client.
+	Stats() Stats
 }
 
+// Option is an optional setting that can be passed to NewFTPClient.
+type Option func(*fuseImpl)
+
 // NewFTPClient returns an implementation of the fuse.FileSystemInterface that is backed by
 // an FTP server connection tp the address. The dir parameter is the directory that the
 // FTP server changes to when connecting.
-func NewFTPClient(ctx context.Context, addr netip.AddrPort, dir string, readTimeout time.Duration) (FTPClient, error) {
-	ctx, cancel := context.WithCancel(ctx)
+func NewFTPClient(ctx context.Context, addr netip.AddrPort, dir string, readTimeout time.Duration, opts ...Option) (FTPClient, error) {
 	f := &fuseImpl{
-		cancel:  cancel,
 		current: make(map[uint64]*info),
 		pool: connPool{
-			dir:     dir,
-			timeout: readTimeout,
+			dir: dir,
 		},
 	}
-	go func() {
-		ticker := time.NewTicker(stalePeriod)
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				f.pool.tidy()
-			}
-		}
-	}()
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.pool.timeouts = f.pool.timeouts.withDefaults(readTimeout)
+	f.pool.onEvent = f.onEvent
+	f.pool.replies = &f.stats
+	if f.pool.tracer == nil {
+		f.pool.tracer = otel.Tracer(tracerName)
+	}
+	if err := f.identity.Validate(); err != nil {
+		return nil, err
+	}
+	var err error
+	if f.errorMap, err = compileErrorRules(f.errorRules); err != nil {
+		return nil, err
+	}
+	if f.pool.dialer, err = newDialer(f.pool.proxyURL); err != nil {
+		return nil, err
+	}
+	f.upload = newLimiter(f.rateLimits.Upload)
+	f.download = newLimiter(f.rateLimits.Download)
+
+	ctx, f.cancel = context.WithCancel(ctx)
+	f.ctx = ctx
+	go f.maintainPools(ctx)
 
 	if err := f.pool.setAddr(addr); err != nil {
-		cancel()
+		f.cancel()
 		return nil, err
 	}
 	return f, nil
 }
 
 func (f *fuseImpl) SetAddress(addr netip.AddrPort) error {
-	return f.pool.setAddr(addr)
+	for _, p := range f.pools() {
+		if err := p.setAddr(addr); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Create will create a file of size zero unless the file already exists
 // The third argument, the mode bits, are currently ignored
-func (f *fuseImpl) Create(path string, flags int, _ uint32) (int, uint64) {
-	log.Debugf("Create(%s, %#x)", path, flags)
-	fe, _, errCode := f.openHandle(path, flags|fuse.O_CREAT)
+func (f *fuseImpl) Create(path string, flags int, _ uint32) (errCode int, fh uint64) {
+	ctx, end := f.startOp("Create", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode, 0
+	}
+	f.logger().Debugf("Create(%s, %#x)", path, flags)
+	fe, _, errCode := f.openHandle(ctx, path, flags|fuse.O_CREAT)
 	if errCode < 0 {
 		return errCode, 0
 	}
 	return 0, fe.fh
 }
 
-// Destroy will drain all ongoing writes, and for each active connection, send the QUIT message to the FTP server and disconnect
+// Destroy will drain all ongoing writes, and for each active connection, send the QUIT message to the FTP server and disconnect.
+// Calls after the first one have no effect.
 func (f *fuseImpl) Destroy() {
-	log.Debug("Destroy")
+	f.logger().Debug("Destroy")
+	f.mounted.Store(false)
+	if f.destroyed.Swap(true) {
+		return
+	}
 
 	f.Lock()
 	// Prevent new entries from being added
@@ -183,55 +330,71 @@ func (f *fuseImpl) Destroy() {
 		}(fe)
 	}
 	wg.Wait()
-	f.pool.quit()
+	for _, p := range f.pools() {
+		p.quit()
+	}
 	f.cancel()
 }
 
 // Flush is a noop in this implementation
-func (f *fuseImpl) Flush(path string, fh uint64) int {
-	log.Debugf("Flush(%s, %d)", path, fh)
+func (f *fuseImpl) Flush(path string, fh uint64) (errCode int) {
+	_, end := f.startOp("Flush", path)
+	defer end(&errCode)
+	f.logger().Debugf("Flush(%s, %d)", path, fh)
 	return 0
 }
 
-// Getattr gets file attributes. The UID and GID will always be the
-// UID and GID of the caller. File mode is always 0644 and Directory
-// mode is always 0755.
-func (f *fuseImpl) Getattr(path string, s *fuse.Stat_t, fh uint64) int {
+// Getattr gets file attributes. The owner and permissions are determined by the
+// Identity of the client. The attributes of entries that Readdir has returned recently
+// are used without asking the FTP server.
+func (f *fuseImpl) Getattr(path string, s *fuse.Stat_t, fh uint64) (errCode int) {
+	ctx, end := f.startOp("Getattr", path)
+	defer end(&errCode)
 	if runtime.GOOS == "darwin" {
 		fn := filepath.Base(path)
 		if fn == ".DS_Store" || strings.HasPrefix("._", fn) {
 			return -fuse.ENOENT
 		}
 	}
-	log.Debugf("Getattr(%s, %d)", path, fh)
-	var e *ftp.Entry
-	var errCode int
+	f.logger().Debugf("Getattr(%s, %d)", path, fh)
+	var e *entry
 	if fh != math.MaxUint64 {
 		e, errCode = f.loadEntry(fh)
 	}
 	if e == nil {
-		e, errCode = f.getEntry(path)
+		e, errCode = f.getEntry(ctx, path)
 	}
 	if errCode == 0 {
-		toStat(e, s)
+		f.stat(path, e, s)
 	}
 	return errCode
 }
 
-// Init starts the garbage collector that removes cached items when they
-// haven't been used for a period of time.
+// Init is called when the file system has been mounted.
 func (f *fuseImpl) Init() {
-	log.Debug("Init")
+	f.logger().Debug("Init")
+	f.mounted.Store(true)
+	f.mountedReadOnly.Store(f.readOnly.Load())
 }
 
-func (f *fuseImpl) Mkdir(path string, mode uint32) int {
-	log.Debugf("Mkdir(%s, %O)", path, mode)
-	err := f.withConn(func(conn *ftp.ServerConn) error {
-		return conn.MakeDir(relpath(path))
+func (f *fuseImpl) Mkdir(path string, mode uint32) (errCode int) {
+	ctx, end := f.startOp("Mkdir", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Mkdir(%s, %O)", path, mode)
+	if errCode = f.checkParentAccess(ctx, path); errCode != 0 {
+		return errCode
+	}
+	err := f.withConn(ctx, func(conn *ftpConn) error {
+		return f.pool.traceCmd(ctx, "MKD", path, func() error {
+			return conn.MakeDir(relpath(path))
+		})
 	})
 	var tpe *textproto.Error
 	if errors.As(err, &tpe) && tpe.Code == ftp.StatusFileUnavailable {
-		if _, ec := f.getEntry(path); ec == 0 {
+		if _, ec := f.getEntry(ctx, path); ec == 0 {
 			return -fuse.EEXIST
 		}
 	}
@@ -240,20 +403,29 @@ func (f *fuseImpl) Mkdir(path string, mode uint32) int {
 
 // Open ensures checks if the file exists, and if it doesn't, ensure that
 // a file of size zero can be created in the server.
-func (f *fuseImpl) Open(path string, flags int) (int, uint64) {
-	log.Debugf("Open(%s, %#x)", path, flags)
-	fe, _, errCode := f.openHandle(path, flags)
+func (f *fuseImpl) Open(path string, flags int) (errCode int, fh uint64) {
+	ctx, end := f.startOp("Open", path)
+	defer end(&errCode)
+	if flags&writeFlags != 0 {
+		if errCode = f.denyWrite(); errCode != 0 {
+			return errCode, 0
+		}
+	}
+	f.logger().Debugf("Open(%s, %#x)", path, flags)
+	fe, _, errCode := f.openHandle(ctx, path, flags)
 	if errCode < 0 {
 		return errCode, 0
 	}
-	log.Debugf("Open(%s, %#x) -> %d", path, flags, fe.fh)
+	f.logger().Debugf("Open(%s, %#x) -> %d", path, flags, fe.fh)
 	return 0, fe.fh
 }
 
 // Opendir is like Open but will fail unless the path represents a directory
-func (f *fuseImpl) Opendir(path string) (int, uint64) {
-	log.Debugf("Opendir(%s)", path)
-	fe, e, errCode := f.openHandle(path, fuse.O_RDONLY)
+func (f *fuseImpl) Opendir(path string) (errCode int, fh uint64) {
+	ctx, end := f.startOp("Opendir", path)
+	defer end(&errCode)
+	f.logger().Debugf("Opendir(%s)", path)
+	fe, e, errCode := f.openHandle(ctx, path, fuse.O_RDONLY)
 	if errCode < 0 {
 		return errCode, 0
 	}
@@ -261,7 +433,7 @@ func (f *fuseImpl) Opendir(path string) (int, uint64) {
 		f.delete(fe.fh)
 		return -fuse.ENOTDIR, 0
 	}
-	log.Debugf("Opendir(%s) -> %d", path, fe.fh)
+	f.logger().Debugf("Opendir(%s) -> %d", path, fe.fh)
 	return 0, fe.fh
 }
 
@@ -271,14 +443,17 @@ func (f *fuseImpl) Opendir(path string) (int, uint64) {
 //
 // Read requires that fuse is started with -o sync_read to ensure that the
 // read calls arrive in sequence.
-func (f *fuseImpl) Read(path string, buff []byte, ofst int64, fh uint64) int {
-	log.Debugf("Read(%s, sz=%d, off=%d, %d)", path, len(buff), ofst, fh)
+func (f *fuseImpl) Read(path string, buff []byte, ofst int64, fh uint64) (errCode int) {
+	ctx, end := f.startOp("Read", path)
+	defer end(&errCode)
+	f.logger().Debugf("Read(%s, sz=%d, off=%d, %d)", path, len(buff), ofst, fh)
 	fe, errCode := f.loadHandle(fh)
 	if errCode < 0 {
 		return errCode
 	}
 
 	of := uint64(ofst)
+	defer fe.pool.startOp(fe.conn)()
 
 	if fe.rof != of && fe.rr != nil {
 		// Restart the read with new offset
@@ -286,32 +461,56 @@ func (f *fuseImpl) Read(path string, buff []byte, ofst int64, fh uint64) int {
 		fe.rr = nil
 	}
 
-	if fe.rr == nil {
-		// Obtain the ftp.Response. It acts as an io.Reader
-		rr, err := fe.conn.RetrFrom(relpath(path), of)
-		if errCode = f.errToFuseErr(err); errCode < 0 {
-			return errCode
-		}
-		fe.rr = rr
-		fe.rof = of
-	}
-
 	bytesRead := 0
-	bytesToRead := len(buff)
-	for bytesToRead-bytesRead > 0 {
-		n, err := fe.rr.Read(buff[bytesRead:])
-		bytesRead += n
-		if err != nil {
-			if err == io.EOF {
-				// Retain the ftp.Response until the file handle is released
-				break
+	err := f.interruptible(fe.conn, func() error {
+		if fe.rr == nil {
+			// Obtain the ftp.Response. It acts as an io.Reader
+			var rr *ftp.Response
+			err := fe.pool.traceCmd(ctx, "RETR", path, func() (err error) {
+				rr, err = fe.conn.RetrFrom(relpath(path), of)
+				return err
+			})
+			if err != nil {
+				return err
 			}
-			if errCode = f.errToFuseErr(err); errCode < 0 {
-				return errCode
+			fe.rr = rr
+			fe.rof = of
+		}
+		bytesToRead := len(buff)
+		for bytesToRead-bytesRead > 0 {
+			n, err := fe.rr.Read(buff[bytesRead:])
+			bytesRead += n
+			if err != nil {
+				if err == io.EOF {
+					// Retain the ftp.Response until the file handle is released
+					break
+				}
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		// The response cannot be trusted after an error, so a new one must be
+		// obtained on the next read.
+		if fe.rr != nil {
+			_ = fe.rr.Close()
+			fe.rr = nil
+		}
+		if !fe.conn.aborted.Load() {
+			f.emit(Event{Type: EventTransferError, Path: path, Message: err.Error()})
+		}
+		fe.renewConn()
+		return f.errToFuseErr(err)
 	}
 	fe.rof += uint64(bytesRead)
+	f.stats.bytesRead.Add(uint64(bytesRead))
+	if err = f.waitForCaller(ctx, bytesRead, f.download, fe.download); err != nil {
+		// The data is not returned, so a read of the same offset must restart the transfer.
+		_ = fe.rr.Close()
+		fe.rr = nil
+		return f.errToFuseErr(err)
+	}
 
 	// Errors are always negative and Read expects the number of bytes read to be returned here.
 	return bytesRead
@@ -322,66 +521,122 @@ func relpath(path string) string {
 }
 
 // Readdir will read the remote directory using an MLSD command and call the given fill function
-// for each entry that was found. The ofst parameter is ignored.
-func (f *fuseImpl) Readdir(path string, fill func(name string, stat *fuse.Stat_t, ofst int64) bool, _ int64, fh uint64) int {
-	log.Debugf("ReadDir(%s, %d)", path, fh)
+// for each entry, starting with the entry at the given offset, until fill asks to stop. The
+// entries are streamed into a listing that belongs to the handle, so that an offset that fill
+// has been given can be used to continue later. Reading from offset zero again, once the listing
+// has ended, starts a new listing.
+func (f *fuseImpl) Readdir(path string, fill func(name string, stat *fuse.Stat_t, ofst int64) bool, ofst int64, fh uint64) (errCode int) {
+	ctx, end := f.startOp("Readdir", path)
+	defer end(&errCode)
+	f.logger().Debugf("ReadDir(%s, %d, %d)", path, ofst, fh)
+	if ofst < 0 {
+		return -fuse.EINVAL
+	}
 	var fe *info
-	var errCode int
 	if fh == math.MaxUint64 {
-		fe, _, errCode = f.openHandle(path, fuse.O_RDONLY)
-		defer f.delete(fe.fh)
+		fe, _, errCode = f.openHandle(ctx, path, fuse.O_RDONLY)
 	} else {
 		fe, errCode = f.loadHandle(fh)
 	}
 	if errCode < 0 {
 		return errCode
 	}
-	es, err := fe.conn.List(relpath(path))
-	errCode = f.errToFuseErr(err)
-	if errCode < 0 {
-		return errCode
+	if fh == math.MaxUint64 {
+		defer f.delete(fe.fh)
 	}
-	for _, e := range es {
-		s := &fuse.Stat_t{}
-		toStat(e, s)
-		if !fill(e.Name, s, 0) {
-			break
-		}
+	l := fe.listing
+	if l == nil || ofst == 0 && l.hasEnded() {
+		fe.renewConn()
+		l = f.listDir(ctx, fe)
+		fe.listing = l
 	}
-	return 0
+	err := f.interruptible(l.conn, func() error {
+		for ; ; ofst++ {
+			e, err := l.get(ofst)
+			if e == nil {
+				if err == nil {
+					f.attrs.ended(fe.pool, path, l)
+				}
+				return err
+			}
+			s := &fuse.Stat_t{}
+			f.stat(joinPath(path, e.Name), e, s)
+			f.attrs.put(fe.pool, path, l, e)
+			if !fill(e.Name, s, ofst+1) {
+				return nil
+			}
+		}
+	})
+	return f.errToFuseErr(err)
 }
 
 // Release will release the resources associated with the given file handle
-func (f *fuseImpl) Release(path string, fh uint64) int {
-	log.Debugf("Release(%s, %d)", path, fh)
+func (f *fuseImpl) Release(path string, fh uint64) (errCode int) {
+	_, end := f.startOp("Release", path)
+	defer end(&errCode)
+	f.logger().Debugf("Release(%s, %d)", path, fh)
 	f.delete(fh)
 	return 0
 }
 
 // Releasedir will release the resources associated with the given file handle
-func (f *fuseImpl) Releasedir(path string, fh uint64) int {
-	log.Debugf("Releasedir(%s, %d)", path, fh)
+func (f *fuseImpl) Releasedir(path string, fh uint64) (errCode int) {
+	_, end := f.startOp("Releasedir", path)
+	defer end(&errCode)
+	f.logger().Debugf("Releasedir(%s, %d)", path, fh)
 	f.delete(fh)
 	return 0
 }
 
 // Rename will rename or move oldpath to newpath
-func (f *fuseImpl) Rename(oldpath string, newpath string) int {
-	log.Debugf("Rename(%s, %s)", oldpath, newpath)
+func (f *fuseImpl) Rename(oldpath string, newpath string) (errCode int) {
+	ctx, end := f.startOp("Rename", oldpath)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Rename(%s, %s)", oldpath, newpath)
 	if oldpath == newpath {
 		return 0
 	}
-	err := f.withConn(func(conn *ftp.ServerConn) error {
-		return conn.Rename(relpath(oldpath), relpath(newpath))
+	if errCode = f.checkParentAccess(ctx, oldpath); errCode != 0 {
+		return errCode
+	}
+	if path.Dir(oldpath) != path.Dir(newpath) {
+		if errCode = f.checkParentAccess(ctx, newpath); errCode != 0 {
+			return errCode
+		}
+	}
+	err := f.withConn(ctx, func(conn *ftpConn) error {
+		return f.pool.traceCmd(ctx, "RENAME", oldpath, func() error {
+			return conn.Rename(relpath(oldpath), relpath(newpath))
+		})
 	})
+	if err == nil {
+		f.inodes.rename(oldpath, newpath)
+		f.attrs.remove(oldpath)
+		f.attrs.remove(newpath)
+	}
 	return f.errToFuseErr(err)
 }
 
 // Rmdir removes the directory at path. The directory must be empty
-func (f *fuseImpl) Rmdir(path string) int {
-	log.Debugf("Rmdir(%s)", path)
-	err := f.withConn(func(conn *ftp.ServerConn) error {
-		e, err := conn.GetEntry(relpath(path))
+func (f *fuseImpl) Rmdir(path string) (errCode int) {
+	ctx, end := f.startOp("Rmdir", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Rmdir(%s)", path)
+	if errCode = f.checkParentAccess(ctx, path); errCode != 0 {
+		return errCode
+	}
+	err := f.withConn(ctx, func(conn *ftpConn) error {
+		var e *ftp.Entry
+		err := f.pool.traceCmd(ctx, "MLST", path, func() (err error) {
+			e, err = conn.GetEntry(relpath(path))
+			return err
+		})
 		if err != nil {
 			return err
 		}
@@ -389,22 +644,24 @@ func (f *fuseImpl) Rmdir(path string) int {
 			return &fs.PathError{
 				Op:   "rmdir",
 				Path: path,
-				Err: &textproto.Error{
-					Code: ftp.StatusFile,
-					Msg:  "not a directory",
-				},
+				Err:  errNotDir,
 			}
 		}
-		if err := conn.RemoveDir(relpath(path)); err != nil {
+		err = f.pool.traceCmd(ctx, "RMD", path, func() error {
+			return conn.RemoveDir(relpath(path))
+		})
+		if err != nil {
 			return err
 		}
 		f.clearPath(path)
+		f.inodes.remove(path)
+		f.attrs.remove(path)
 		return nil
 	})
 	var tpe *textproto.Error
 	if errors.As(err, &tpe) && tpe.Code == ftp.StatusFileUnavailable {
-		log.Debugf("%s is unavailable", path)
-		if _, ec := f.getEntry(path); ec == 0 {
+		f.logger().Debugf("%s is unavailable", path)
+		if _, ec := f.getEntry(ctx, path); ec == 0 {
 			return -fuse.ENOTEMPTY
 		}
 	}
@@ -413,60 +670,99 @@ func (f *fuseImpl) Rmdir(path string) int {
 
 // Truncate will truncate the given file to a certain size using a STOR command
 // with zero bytes and an offset. This behavior will only work with some servers.
-func (f *fuseImpl) Truncate(path string, size int64, fh uint64) int {
-	log.Debugf("Truncate(%s, sz=%d, %d)", path, size, fh)
+func (f *fuseImpl) Truncate(path string, size int64, fh uint64) (errCode int) {
+	ctx, end := f.startOp("Truncate", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Truncate(%s, sz=%d, %d)", path, size, fh)
 	var fe *info
-	var errCode int
 	if fh == math.MaxUint64 {
-		fe, _, errCode = f.openHandle(path, fuse.O_WRONLY)
-		defer f.delete(fe.fh)
+		fe, _, errCode = f.openHandle(ctx, path, fuse.O_WRONLY)
 	} else {
 		fe, errCode = f.loadHandle(fh)
 	}
 	if errCode < 0 {
 		return errCode
 	}
+	if fh == math.MaxUint64 {
+		defer f.delete(fe.fh)
+	}
+	defer fe.pool.startOp(fe.conn)()
 	sz := uint64(size)
-	if errCode = f.errToFuseErr(fe.conn.StorFrom(relpath(path), bytes.NewReader(nil), sz)); errCode < 0 {
-		return errCode
+	err := f.interruptible(fe.conn, func() error {
+		return fe.pool.traceCmd(ctx, "STOR", path, func() error {
+			return fe.conn.StorFrom(relpath(path), bytes.NewReader(nil), sz)
+		})
+	})
+	if err != nil {
+		fe.renewConn()
+		return f.errToFuseErr(err)
 	}
 	if sz < fe.entry.Size {
 		fe.entry.Size = sz
 	}
+	f.attrs.remove(path)
 	return 0
 }
 
 // Unlink will remove the path from the file system.
-func (f *fuseImpl) Unlink(path string) int {
-	log.Debugf("Unlink(%s)", path)
-	return f.errToFuseErr(f.withConn(func(conn *ftp.ServerConn) error {
-		if err := conn.Delete(relpath(path)); err != nil {
+func (f *fuseImpl) Unlink(path string) (errCode int) {
+	ctx, end := f.startOp("Unlink", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Unlink(%s)", path)
+	if errCode = f.checkParentAccess(ctx, path); errCode != 0 {
+		return errCode
+	}
+	return f.errToFuseErr(f.withConn(ctx, func(conn *ftpConn) error {
+		err := f.pool.traceCmd(ctx, "DELE", path, func() error {
+			return conn.Delete(relpath(path))
+		})
+		if err != nil {
 			return err
 		}
 		f.clearPath(path)
+		f.inodes.remove(path)
+		f.attrs.remove(path)
 		return nil
 	}))
 }
 
-func (i *info) pipeCopy(of uint64) int {
+func (i *info) pipeCopy(ctx context.Context, of uint64) int {
 	// A connection dedicated to the Write function is needed because there
 	// might be simultaneous Read and Write operations on the same file handle.
-	conn, err := i.pool.get()
+	conn, err := i.pool.get(ctx)
 	if errCode := i.errToFuseErr(err); errCode < 0 {
 		return errCode
 	}
 	i.wof = of
-	var reader io.ReadCloser
+	i.wconn = conn
+	var reader *io.PipeReader
 	reader, i.writer = io.Pipe()
+	lr := &limitedReader{
+		Reader:   reader,
+		ctx:      i.ctx,
+		limiters: []*rate.Limiter{i.fuseImpl.upload, i.upload},
+	}
 	i.wg.Add(1)
 	go func() {
 		defer func() {
 			i.wg.Done()
 			i.pool.put(conn)
 		}()
-		if err := conn.StorFrom(relpath(i.path), reader, of); err != nil {
-			log.Errorf("error storing: %v", err)
+		err := i.pool.traceCmd(ctx, "STOR", i.path, func() error {
+			return conn.StorFrom(relpath(i.path), lr, of)
+		})
+		if err != nil && !conn.aborted.Load() {
+			i.logger().Errorf("error storing: %v", err)
+			i.emit(Event{Type: EventTransferError, Path: i.path, Message: err.Error()})
 		}
+		// Ensure that a Write that is blocked on the pipe returns when the transfer ends.
+		_ = reader.CloseWithError(err)
 	}()
 	return 0
 }
@@ -474,8 +770,13 @@ func (i *info) pipeCopy(of uint64) int {
 // Write writes the given data to a file at the given offset in that file. The data
 // connection that is established to facilitate the data transfer will remain open
 // until the handle is released by a call to Release
-func (f *fuseImpl) Write(path string, buf []byte, ofst int64, fh uint64) int {
-	log.Debugf("Write(%s, sz=%d, off=%d, %d)", path, len(buf), ofst, fh)
+func (f *fuseImpl) Write(path string, buf []byte, ofst int64, fh uint64) (errCode int) {
+	ctx, end := f.startOp("Write", path)
+	defer end(&errCode)
+	if errCode = f.denyWrite(); errCode != 0 {
+		return errCode
+	}
+	f.logger().Debugf("Write(%s, sz=%d, off=%d, %d)", path, len(buf), ofst, fh)
 	fe, errCode := f.loadHandle(fh)
 	if errCode < 0 {
 		return errCode
@@ -486,21 +787,32 @@ func (f *fuseImpl) Write(path string, buf []byte, ofst int64, fh uint64) int {
 	if fe.writer == nil {
 		// start the pipe pumper. It ends when the fe.writer closes. That
 		// happens when Release is called
-		ec = fe.pipeCopy(of)
+		ec = fe.pipeCopy(ctx, of)
 	} else if fe.wof != of {
 		// Drain and restart the write operation.
 		_ = fe.writer.Close()
 		fe.wg.Wait()
-		ec = fe.pipeCopy(of)
+		ec = fe.pipeCopy(ctx, of)
 	}
 	if ec != 0 {
 		return ec
 	}
-	n, err := fe.writer.Write(buf)
+	var n int
+	err := f.interruptible(fe.wconn, func() (err error) {
+		n, err = fe.writer.Write(buf)
+		return err
+	})
+	if errors.Is(err, errInterrupted) {
+		// The transfer has been aborted, so the next Write must start a new one.
+		_ = fe.writer.Close()
+		fe.wg.Wait()
+		fe.writer = nil
+	}
 	if errCode = f.errToFuseErr(err); errCode < 0 {
 		n = errCode
 	} else {
 		fe.wof += uint64(n)
+		f.stats.bytesWritten.Add(uint64(n))
 		if fe.wof > fe.entry.Size {
 			fe.entry.Size = fe.wof
 		}
@@ -518,8 +830,9 @@ func (f *fuseImpl) cacheSize() int {
 func (f *fuseImpl) clearPath(p string) {
 	var pf []*info
 	f.RLock()
+	prefix := strings.TrimSuffix(p, "/") + "/"
 	for _, fe := range f.current {
-		if strings.HasPrefix(fe.path, p) {
+		if fe.path == p || strings.HasPrefix(fe.path, prefix) {
 			pf = append(pf, fe)
 		}
 	}
@@ -529,7 +842,8 @@ func (f *fuseImpl) clearPath(p string) {
 		f.Lock()
 		delete(f.current, fe.fh)
 		f.Unlock()
-		f.pool.put(fe.conn)
+		fe.pool.put(fe.conn)
+		f.emit(Event{Type: EventCacheEviction, Path: fe.path})
 	}
 }
 
@@ -542,86 +856,59 @@ func (f *fuseImpl) delete(fh uint64) {
 		f.Lock()
 		delete(f.current, fe.fh)
 		f.Unlock()
-		f.pool.put(fe.conn)
+		fe.pool.put(fe.conn)
 	}
 }
 
-func (f *fuseImpl) errToFuseErr(err error) int {
-	if err == nil {
-		return 0
+// getEntry returns the entry of the given path. The entry of an open handle is preferred over
+// the one reported by the FTP server, because the server doesn't know about pending writes.
+func (f *fuseImpl) getEntry(ctx context.Context, path string) (e *entry, fuseErr int) {
+	if e = f.openEntry(path, ""); e != nil {
+		return e, 0
 	}
-
-	var tpe *textproto.Error
-	if errors.As(err, &tpe) {
-		if tpe.Code == ftp.StatusCommandOK {
-			return 0
-		}
-		switch tpe.Code {
-		case ftp.StatusClosingDataConnection:
-			return -fuse.ECONNABORTED
-		case ftp.StatusNotAvailable:
-			return -fuse.EADDRNOTAVAIL
-		case ftp.StatusCanNotOpenDataConnection:
-			return -fuse.ECONNREFUSED
-		case ftp.StatusTransfertAborted:
-			return -fuse.ECONNABORTED
-		case ftp.StatusInvalidCredentials:
-			return -fuse.EACCES
-		case ftp.StatusHostUnavailable:
-			return -fuse.EHOSTUNREACH
-		case ftp.StatusBadFileName:
-			return -fuse.EINVAL
-		case ftp.StatusFileUnavailable:
-			return -fuse.ENOENT
+	pool, err := f.callerPool()
+	if err != nil {
+		return nil, f.errToFuseErr(err)
+	}
+	if e = f.attrs.get(pool, path); e == nil {
+		err = f.withConn(ctx, func(conn *ftpConn) error {
+			return f.pool.traceCmd(ctx, "MLST", path, func() (err error) {
+				e, err = conn.stat(relpath(path))
+				return err
+			})
+		})
+		if err != nil {
+			return nil, f.errToFuseErr(err)
 		}
 	}
-	em := err.Error()
-	switch {
-	case
-		strings.Contains(em, errConnAborted),
-		strings.Contains(em, errClosed):
-		return -fuse.ECONNABORTED
-	case containsAny(em, fs.ErrNotExist.Error(), errFileNotFound, errDirNotFound):
-		return -fuse.ENOENT
-	case strings.Contains(em, errDirNotEmpty):
-		return -fuse.ENOTEMPTY
-	case strings.Contains(em, errBrokenPipe):
-		return -fuse.EPIPE
-	case strings.Contains(em, io.EOF.Error()):
-		return -fuse.EIO
-	case strings.Contains(em, errConnRefused):
-		return -fuse.ECONNREFUSED
-	case strings.Contains(em, errIOTimeout):
-		return -fuse.ETIMEDOUT
-	case strings.Contains(em, errFileExists):
-		return -fuse.EEXIST
-	default:
-		// TODO
-		buf := make([]byte, 0x10000)
-		n := runtime.Stack(buf, false)
-		log.Printf("%T %v\n%s", err, err, string(buf[:n]))
-		return -fuse.EIO
+	if unique := e.facts["unique"]; unique != "" {
+		if oe := f.openEntry(path, unique); oe != nil {
+			return oe, 0
+		}
 	}
+	return e, 0
 }
 
-func (f *fuseImpl) getEntry(path string) (e *ftp.Entry, fuseErr int) {
+// openEntry returns the entry of an open handle of the given path, or, when unique isn't empty,
+// of an open handle of a path with that unique fact, i.e. a hard link of the path. It returns
+// nil when there is no such handle.
+func (f *fuseImpl) openEntry(p, unique string) *entry {
 	f.RLock()
+	defer f.RUnlock()
 	for _, fe := range f.current {
-		if fe.path == path {
-			f.RUnlock()
-			return &fe.entry, 0
+		switch {
+		case fe.path == p:
+			return &fe.entry
+		case unique != "" && fe.entry.facts["unique"] == unique:
+			le := fe.entry
+			le.Name = path.Base(p)
+			return &le
 		}
 	}
-	f.RUnlock()
-	err := f.withConn(func(conn *ftp.ServerConn) error {
-		var err error
-		e, err = conn.GetEntry(relpath(path))
-		return err
-	})
-	return e, f.errToFuseErr(err)
+	return nil
 }
 
-func (f *fuseImpl) loadEntry(fh uint64) (*ftp.Entry, int) {
+func (f *fuseImpl) loadEntry(fh uint64) (*entry, int) {
 	f.RLock()
 	fe, ok := f.current[fh]
 	f.RUnlock()
@@ -641,14 +928,18 @@ func (f *fuseImpl) loadHandle(fh uint64) (*info, int) {
 	return fe, 0
 }
 
-func (f *fuseImpl) openHandle(path string, flags int) (nfe *info, e *ftp.Entry, errCode int) {
+func (f *fuseImpl) openHandle(ctx context.Context, path string, flags int) (nfe *info, e *entry, errCode int) {
 	f.RLock()
 	shuttingDown := f.shuttingDown
 	f.RUnlock()
 	if shuttingDown {
 		return nil, nil, -fuse.ECANCELED
 	}
-	conn, err := f.pool.get()
+	pool, err := f.callerPool()
+	if ec := f.errToFuseErr(err); ec < 0 {
+		return nil, nil, ec
+	}
+	conn, err := pool.get(ctx)
 	ec := f.errToFuseErr(err)
 	if ec < 0 {
 		return nil, nil, ec
@@ -656,29 +947,48 @@ func (f *fuseImpl) openHandle(path string, flags int) (nfe *info, e *ftp.Entry,
 
 	defer func() {
 		if errCode != 0 {
-			f.pool.put(conn)
+			pool.put(conn)
 		}
 	}()
+	defer pool.startOp(conn)()
 
-	if e, err = conn.GetEntry(relpath(path)); err != nil {
+	err = f.interruptible(conn, func() error {
+		return pool.traceCmd(ctx, "MLST", path, func() (err error) {
+			e, err = conn.stat(relpath(path))
+			return err
+		})
+	})
+	if err != nil {
 		errCode = f.errToFuseErr(err)
 		if !(flags&fuse.O_CREAT == fuse.O_CREAT && errCode == -fuse.ENOENT) {
 			return nil, nil, errCode
 		}
+		if errCode = f.checkParentAccess(ctx, path); errCode != 0 {
+			return nil, nil, errCode
+		}
 
 		// Create an empty file to ensure that it can be created
-		if ec = f.errToFuseErr(conn.Stor(relpath(path), bytes.NewReader(nil))); ec < 0 {
+		err = f.interruptible(conn, func() error {
+			return pool.traceCmd(ctx, "STOR", path, func() error {
+				return conn.Stor(relpath(path), bytes.NewReader(nil))
+			})
+		})
+		if ec = f.errToFuseErr(err); ec < 0 {
 			return nil, nil, ec
 		}
-		e = &ftp.Entry{
+		f.attrs.remove(path)
+		e = &entry{Entry: ftp.Entry{
 			Name: filepath.Base(path),
 			Type: ftp.EntryTypeFile,
 			Time: time.Now(),
-		}
+		}}
 	} else {
 		if flags&(fuse.O_RDWR|fuse.O_WRONLY) != 0 && e.Type == ftp.EntryTypeFolder {
 			return nil, nil, -fuse.EISDIR
 		}
+		if errCode = f.checkAccess(e, openMask(flags)); errCode != 0 {
+			return nil, nil, errCode
+		}
 	}
 
 	f.Lock()
@@ -689,7 +999,10 @@ func (f *fuseImpl) openHandle(path string, flags int) (nfe *info, e *ftp.Entry,
 		path:     path,
 		fh:       fh,
 		conn:     conn,
+		pool:     pool,
 		entry:    *e,
+		upload:   newLimiter(f.rateLimits.HandleUpload),
+		download: newLimiter(f.rateLimits.HandleDownload),
 	}
 	if flags&fuse.O_APPEND == fuse.O_APPEND {
 		nfe.wof = e.Size
@@ -699,28 +1012,27 @@ func (f *fuseImpl) openHandle(path string, flags int) (nfe *info, e *ftp.Entry,
 	return nfe, e, 0
 }
 
-func (f *fuseImpl) withConn(fn func(conn *ftp.ServerConn) error) error {
-	conn, err := f.pool.get()
+func (f *fuseImpl) withConn(ctx context.Context, fn func(conn *ftpConn) error) error {
+	pool, err := f.callerPool()
 	if err != nil {
 		return err
 	}
-	err = fn(conn)
-	f.pool.put(conn)
-	return err
-}
-
-func containsAny(str string, ss ...string) bool {
-	for _, s := range ss {
-		if strings.Contains(str, s) {
-			return true
-		}
+	conn, err := pool.get(ctx)
+	if err != nil {
+		return err
 	}
-	return false
+	endOp := pool.startOp(conn)
+	err = f.interruptible(conn, func() error {
+		return fn(conn)
+	})
+	endOp()
+	pool.put(conn)
+	return err
 }
 
+// toStat fills in the given stat from the given entry using the default permissions. The
+// owner and permissions are set by Identity.apply.
 func toStat(e *ftp.Entry, s *fuse.Stat_t) {
-	// TODO: ftp actually returns a line similar to what 'ls -l' produces. It is
-	// possible to parse the mode from that but the client we use here doesn't do that.
 	switch e.Type {
 	case ftp.EntryTypeFolder:
 		s.Mode = fuse.S_IFDIR | 0755
@@ -735,8 +1047,6 @@ func toStat(e *ftp.Entry, s *fuse.Stat_t) {
 	s.Ctim = tm
 	s.Mtim = tm
 	s.Birthtim = s.Ctim
-	s.Uid = uint32(os.Getuid())
-	s.Gid = uint32(os.Getgid())
 	s.Nlink = 1
 	s.Flags = 0
 }
@@ -151,6 +151,36 @@ func startFUSEHost(t *testing.T, ctx context.Context, port uint16, dir string) (
 	return fsh, host, dir
 }
 
+// startTestServer starts an FTP server that is stopped when the test ends. It returns the
+// directory that the server exports and the address that it listens to.
+func startTestServer(t *testing.T) (string, netip.AddrPort) {
+	ctx, cancel := context.WithCancel(testContext(t))
+	wg := sync.WaitGroup{}
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	root, port := startFTPServer(t, ctx, t.TempDir(), &wg)
+	require.NotEqual(t, uint16(0), port)
+	return root, netip.MustParseAddrPort(fmt.Sprintf("127.0.0.1:%d", port))
+}
+
+// connectTestClient returns a client that is connected to the FTP server at the given address
+// using the given options. The client is destroyed when the test ends.
+func connectTestClient(t *testing.T, addr netip.AddrPort, opts ...Option) *fuseImpl {
+	fsh, err := NewFTPClient(testContext(t), addr, remoteDir, time.Second, opts...)
+	require.NoError(t, err)
+	t.Cleanup(fsh.Destroy)
+	return fsh.(*fuseImpl)
+}
+
+// newTestClient starts an FTP server and returns a client that is connected to it using the
+// given options, together with the directory that the server exports.
+func newTestClient(t *testing.T, opts ...Option) (*fuseImpl, string) {
+	root, addr := startTestServer(t)
+	return connectTestClient(t, addr, opts...), root
+}
+
 func TestConnectFailure(t *testing.T) {
 	ctx := testContext(t)
 	_, err := NewFTPClient(ctx, netip.MustParseAddrPort("198.51.100.32:21"), "", time.Second)
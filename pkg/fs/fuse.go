@@ -5,46 +5,85 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/winfsp/cgofuse/fuse"
 )
 
+// mountCheckInterval is the interval between checks that the file system is still mounted.
+const mountCheckInterval = 2 * time.Second
+
 // FuseHost wraps a fuse.FileSystemHost and adds Start/Stop semantics
 type FuseHost struct {
+	fsh        fuse.FileSystemInterface
+	log        *logrus.Entry
 	host       *fuse.FileSystemHost
 	mountPoint string
+	options    MountOptions
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
+	events     eventEmitter
+	mounted    atomic.Bool
 }
 
 // NewHost creates a FuseHost instance that will mount the given filesystem
-// on the given mountPoint.
+// on the given mountPoint using the DefaultMountOptions.
 func NewHost(fsh fuse.FileSystemInterface, mountPoint string) *FuseHost {
+	return NewHostWithOptions(fsh, mountPoint, DefaultMountOptions())
+}
+
+// NewHostWithOptions creates a FuseHost instance that will mount the given filesystem
+// on the given mountPoint using the given options.
+func NewHostWithOptions(fsh fuse.FileSystemInterface, mountPoint string, options MountOptions) *FuseHost {
 	host := fuse.NewFileSystemHost(fsh)
 	host.SetCapReaddirPlus(true)
-	return &FuseHost{host: host, mountPoint: mountPoint}
+	fh := &FuseHost{fsh: fsh, host: host, mountPoint: mountPoint, options: options}
+	if lo, ok := fsh.(loggerOwner); ok {
+		fh.log = lo.logger()
+	} else {
+		fh.log = logrus.NewEntry(logrus.StandardLogger())
+	}
+	if em, ok := fsh.(eventEmitter); ok {
+		fh.events = em
+	} else {
+		fh.events = EventHandler(nil)
+	}
+	return fh
 }
 
-// Start will mount the filesystem on the mountPoint passed to NewHost.
+// Start will mount the filesystem on the mountPoint passed to NewHost or NewHostWithOptions. An error is returned
+// when the mount options are invalid or unsupported.
 func (fh *FuseHost) Start(ctx context.Context, startTimeout time.Duration) error {
 	ctx, fh.cancel = context.WithCancel(ctx)
 
+	if err := fh.options.Validate(); err != nil {
+		return err
+	}
 	opts := []string{
-		"-o", "default_permissions",
 		"-o", "auto_cache",
 		"-o", "sync_read",
-		"-o", "allow_root",
 	}
-	if logrus.GetLevel() >= logrus.DebugLevel {
+	if ac, ok := fh.fsh.(accessCheckingFS); !ok || !ac.checksAccess() {
+		// Let the kernel check the permissions using the reported owner and mode
+		opts = append(opts, "-o", "default_permissions")
+	}
+	if runtime.GOOS != "windows" {
+		// Report the inode numbers assigned by the file system instead of those of the FUSE library
+		opts = append(opts, "-o", "use_ino")
+	}
+	if fh.log.Logger.IsLevelEnabled(logrus.DebugLevel) {
 		opts = append(opts, "-o", "debug")
 	}
-	if runtime.GOOS == "windows" {
-		// WinFsp requires this to create files with the same
-		// user as the one that starts the FUSE mount
-		opts = append(opts, "-o", "uid=-1", "-o", "gid=-1")
+	if ro, ok := fh.fsh.(readOnlyFS); ok && ro.isReadOnly() {
+		opts = append(opts, "-o", "ro")
 	}
+	mo, err := fh.options.args(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	opts = append(opts, mo...)
 	started := make(chan error, 1)
 	startCtx, startCancel := context.WithTimeout(ctx, startTimeout)
 	defer startCancel()
@@ -64,16 +103,57 @@ func (fh *FuseHost) Start(ctx context.Context, startTimeout time.Duration) error
 	fh.wg.Add(1)
 	go func() {
 		defer fh.wg.Done()
-		select {
-		case <-ctx.Done():
-			fh.host.Unmount()
-		case mountResult := <-mCh:
-			if !mountResult {
-				logrus.Errorf("fuse mount of %s failed", fh.mountPoint)
+		ticker := time.NewTicker(mountCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				fh.host.Unmount()
+				<-mCh
+				fh.unmounted()
+				return
+			case mountResult := <-mCh:
+				if !mountResult {
+					fh.log.Errorf("fuse mount of %s failed", fh.mountPoint)
+				}
+				if fh.unmounted() {
+					fh.died("the FUSE file system was unmounted")
+				}
+				return
+			case <-ticker.C:
+				if fh.mounted.Load() && !fh.isMounted() {
+					// The mount is gone, e.g. because of a "umount", but the FUSE host is
+					// still running. Unmounting the host makes the Mount call return.
+					fh.log.Errorf("%s is no longer mounted", fh.mountPoint)
+					fh.host.Unmount()
+				}
 			}
 		}
 	}()
-	return <-started
+	if err := <-started; err != nil {
+		return err
+	}
+	fh.mounted.Store(true)
+	fh.events.emit(Event{Type: EventMounted})
+	return nil
+}
+
+// unmounted emits the EventUnmounted event, and returns true, unless the file system was
+// never mounted.
+func (fh *FuseHost) unmounted() bool {
+	if fh.mounted.Swap(false) {
+		fh.events.emit(Event{Type: EventUnmounted})
+		return true
+	}
+	return false
+}
+
+// died is called when the file system was unmounted without a call to Stop. It ensures that
+// the file system is destroyed, and emits the EventDead event.
+func (fh *FuseHost) died(reason string) {
+	fh.log.Errorf("fuse mount of %s died: %s", fh.mountPoint, reason)
+	fh.fsh.Destroy()
+	fh.events.emit(Event{Type: EventDead, Message: reason})
 }
 
 // Stop will unmount the file system and terminate the FTP client, wait for all clean-up to
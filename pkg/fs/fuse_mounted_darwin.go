@@ -0,0 +1,17 @@
+package fs
+
+import (
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// isMounted returns true if the file system at the mount point is a FUSE file system. It
+// also returns true if that cannot be determined.
+func (fh *FuseHost) isMounted() bool {
+	var st unix.Statfs_t
+	if err := unix.Statfs(fh.mountPoint, &st); err != nil {
+		return err != unix.ENOTCONN && err != unix.ENXIO
+	}
+	return strings.Contains(unix.ByteSliceToString(st.Fstypename[:]), "fuse")
+}
@@ -0,0 +1,50 @@
+package fs
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// isMounted returns true if the mount point is found in the mount table. It also returns
+// true if the mount table cannot be read.
+func (fh *FuseHost) isMounted() bool {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return true
+	}
+	defer f.Close()
+	mp := filepath.Clean(fh.mountPoint)
+	if abs, err := filepath.Abs(mp); err == nil {
+		mp = abs
+	}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// The fifth field is the mount point, with space, tab, newline, and backslash escaped as octal.
+		fields := strings.Fields(sc.Text())
+		if len(fields) > 4 && unescapeMountInfo(fields[4]) == mp {
+			return true
+		}
+	}
+	return sc.Err() != nil
+}
+
+func unescapeMountInfo(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+3 < len(s) {
+			if c, err := strconv.ParseUint(s[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
@@ -0,0 +1,13 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsMounted(t *testing.T) {
+	assert.True(t, (&FuseHost{mountPoint: "/"}).isMounted())
+	assert.False(t, (&FuseHost{mountPoint: t.TempDir()}).isMounted())
+	assert.Equal(t, "/mnt/a b\\c", unescapeMountInfo(`/mnt/a\040b\134c`))
+}
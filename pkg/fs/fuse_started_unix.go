@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/sirupsen/logrus"
 	"golang.org/x/sys/unix"
 )
 
@@ -40,7 +39,7 @@ func (fh *FuseHost) detectFuseStarted(ctx context.Context, started chan error) {
 		case <-ticker.C:
 			if mountSt, err := statWithTimeout(ctx, fh.mountPoint, 20*time.Millisecond); err != nil {
 				// we don't consider a failure to stat an error here, just a cause for a retry.
-				logrus.Debugf("unable to stat mount point %q: %v", fh.mountPoint, err)
+				fh.log.Debugf("unable to stat mount point %q: %v", fh.mountPoint, err)
 			} else {
 				if st.Ino != mountSt.Ino || st.Dev != mountSt.Dev {
 					return
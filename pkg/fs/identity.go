@@ -0,0 +1,109 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+// Identity controls the owner and permissions that are reported for files and directories.
+// By default, everything is owned by the user and group of the process, files have mode 0644,
+// and directories have mode 0755.
+type Identity struct {
+	// UID and GID are the owner and group of all files and directories that aren't found in
+	// the Owners and Groups tables. A nil value means the user and group of the process.
+	UID *uint32
+	GID *uint32
+
+	// FileMode and DirMode are the permission bits of all files and directories. A nil value
+	// means the default, or the mode reported by the server when UseServerMode is set.
+	FileMode *uint32
+	DirMode  *uint32
+
+	// UseServerMode makes files and directories without a FileMode or DirMode use the
+	// UNIX.mode reported by the server, or the mode of an "ls -l" style listing. The default
+	// mode is used when the server reports none.
+	UseServerMode bool
+
+	// Umask is the permission bits that are cleared from the mode of all files and directories.
+	Umask uint32
+
+	// Owners and Groups map the UNIX.owner or UNIX.uid and the UNIX.group or UNIX.gid reported
+	// by the server, which are names or numeric ids, to local ids.
+	Owners map[string]uint32
+	Groups map[string]uint32
+}
+
+// WithIdentity sets the owner and permissions that are reported for files and directories.
+func WithIdentity(id Identity) Option {
+	return func(f *fuseImpl) {
+		f.identity = id
+	}
+}
+
+// Validate returns an error when a mode or the umask has bits other than the permission bits.
+func (id *Identity) Validate() error {
+	for _, m := range []struct {
+		name  string
+		value *uint32
+	}{{"file mode", id.FileMode}, {"directory mode", id.DirMode}, {"umask", &id.Umask}} {
+		if m.value != nil && *m.value > 0777 {
+			return fmt.Errorf("invalid %s %#o", m.name, *m.value)
+		}
+	}
+	return nil
+}
+
+// stat fills in the given stat from the entry at the given path using the identity and the
+// inode table of the client.
+func (f *fuseImpl) stat(path string, e *entry, s *fuse.Stat_t) {
+	toStat(&e.Entry, s)
+	f.identity.apply(e.facts, s)
+	s.Ino = f.inodes.ino(path, e.facts["unique"])
+}
+
+// apply changes the owner and permissions of the given stat according to the identity, using
+// the given facts of the entry.
+func (id *Identity) apply(facts map[string]string, s *fuse.Stat_t) {
+	s.Uid = id.lookup(id.Owners, facts, id.UID, os.Getuid(), "unix.owner", "unix.uid")
+	s.Gid = id.lookup(id.Groups, facts, id.GID, os.Getgid(), "unix.group", "unix.gid")
+
+	var mode *uint32
+	switch s.Mode & fuse.S_IFMT {
+	case fuse.S_IFDIR:
+		mode = id.DirMode
+	case fuse.S_IFREG:
+		mode = id.FileMode
+	default:
+		return
+	}
+	perm := s.Mode & 0777
+	switch {
+	case mode != nil:
+		perm = *mode
+	case id.UseServerMode:
+		if m, err := strconv.ParseUint(facts["unix.mode"], 8, 32); err == nil {
+			perm = uint32(m) & 0777
+		}
+	}
+	s.Mode = s.Mode&^0777 | perm&^id.Umask
+}
+
+// lookup returns the local id that the table maps one of the given facts to, trying them in
+// order. The given override, or the default when that is nil, is returned when no fact is
+// found in the table.
+func (id *Identity) lookup(table map[string]uint32, facts map[string]string, override *uint32, dflt int, names ...string) uint32 {
+	for _, n := range names {
+		if v, ok := facts[n]; ok {
+			if lid, ok := table[v]; ok {
+				return lid
+			}
+		}
+	}
+	if override != nil {
+		return *override
+	}
+	return uint32(dflt)
+}
@@ -0,0 +1,141 @@
+package fs
+
+import (
+	"context"
+	"math"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestParseListing(t *testing.T) {
+	mlsd := "Type=file;Size=10;Modify=20230101120000;UNIX.mode=0640;UNIX.owner=alice;UNIX.group=staff; a file.txt\r\n" +
+		"type=dir;modify=20230101120000;unix.uid=1001;unix.gid=1001; sub\r\n"
+	assert.Equal(t, map[string]map[string]string{
+		"a file.txt": {"type": "file", "size": "10", "modify": "20230101120000", "unix.mode": "0640", "unix.owner": "alice", "unix.group": "staff"},
+		"sub":        {"type": "dir", "modify": "20230101120000", "unix.uid": "1001", "unix.gid": "1001"},
+	}, parseListing([]byte(mlsd)))
+
+	list := "-rw-r-----   1 alice    staff        10 Jan  1 12:00 a file.txt\r\n" +
+		"drwxr-sr-x   2 1001     1001       4096 Jan  1  2023 sub\r\n" +
+		"lrwxrwxrwx   1 alice    staff         4 Jan  1 12:00 link -> sub\r\n" +
+		"total 3\r\n"
+	assert.Equal(t, map[string]map[string]string{
+		"a file.txt": {"unix.mode": "0640", "unix.owner": "alice", "unix.group": "staff"},
+		"sub":        {"unix.mode": "0755", "unix.owner": "1001", "unix.group": "1001"},
+		"link":       {"unix.mode": "0777", "unix.owner": "alice", "unix.group": "staff"},
+	}, parseListing([]byte(list)))
+}
+
+func TestIdentity(t *testing.T) {
+	u32 := func(v uint32) *uint32 { return &v }
+	facts := map[string]string{"unix.owner": "alice", "unix.gid": "1001", "unix.mode": "0640"}
+
+	t.Run("Default", func(t *testing.T) {
+		id := Identity{}
+		s := &fuse.Stat_t{Mode: fuse.S_IFREG | 0644}
+		id.apply(nil, s)
+		assert.Equal(t, uint32(os.Getuid()), s.Uid)
+		assert.Equal(t, uint32(os.Getgid()), s.Gid)
+		assert.Equal(t, uint32(fuse.S_IFREG|0644), s.Mode)
+
+		// The mode reported by the server is ignored
+		id.apply(facts, s)
+		assert.Equal(t, uint32(fuse.S_IFREG|0644), s.Mode)
+	})
+
+	t.Run("ServerMode", func(t *testing.T) {
+		id := Identity{UseServerMode: true}
+		s := &fuse.Stat_t{Mode: fuse.S_IFREG | 0644}
+		id.apply(facts, s)
+		assert.Equal(t, uint32(fuse.S_IFREG|0640), s.Mode)
+
+		// The default is used when the server reports no mode
+		s = &fuse.Stat_t{Mode: fuse.S_IFDIR | 0755}
+		id.apply(nil, s)
+		assert.Equal(t, uint32(fuse.S_IFDIR|0755), s.Mode)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		id := Identity{UID: u32(1000), GID: u32(100), FileMode: u32(0666), DirMode: u32(0777), Umask: 0022}
+		s := &fuse.Stat_t{Mode: fuse.S_IFREG | 0644}
+		id.apply(facts, s)
+		assert.Equal(t, uint32(1000), s.Uid)
+		assert.Equal(t, uint32(100), s.Gid)
+		assert.Equal(t, uint32(fuse.S_IFREG|0644), s.Mode)
+
+		s = &fuse.Stat_t{Mode: fuse.S_IFDIR | 0755}
+		id.apply(facts, s)
+		assert.Equal(t, uint32(fuse.S_IFDIR|0755), s.Mode)
+	})
+
+	t.Run("Mapping", func(t *testing.T) {
+		id := Identity{
+			UID:    u32(1000),
+			Owners: map[string]uint32{"alice": 2000},
+			Groups: map[string]uint32{"1001": 3000},
+		}
+		s := &fuse.Stat_t{Mode: fuse.S_IFREG | 0644}
+		id.apply(facts, s)
+		assert.Equal(t, uint32(2000), s.Uid)
+		assert.Equal(t, uint32(3000), s.Gid)
+
+		// Owners that aren't in the table get the override
+		id.apply(map[string]string{"unix.owner": "bob"}, s)
+		assert.Equal(t, uint32(1000), s.Uid)
+		assert.Equal(t, uint32(os.Getgid()), s.Gid)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Error(t, (&Identity{FileMode: u32(01777)}).Validate())
+		assert.Error(t, (&Identity{Umask: 01000}).Validate())
+		assert.NoError(t, (&Identity{DirMode: u32(0700), Umask: 077}).Validate())
+		_, err := NewFTPClient(context.Background(), netip.MustParseAddrPort("127.0.0.1:21"), "", time.Second, WithIdentity(Identity{FileMode: u32(01777)}))
+		assert.Error(t, err)
+	})
+}
+
+func TestIdentityAttrs(t *testing.T) {
+	uid, gid, dirMode := uint32(1234), uint32(5678), uint32(0750)
+	f, root := newTestClient(t, WithIdentity(Identity{UID: &uid, GID: &gid, DirMode: &dirMode, Umask: 0027}))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), []byte("Some text\n"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0755))
+
+	s := &fuse.Stat_t{}
+	require.Equal(t, 0, f.Getattr("/test1.txt", s, math.MaxUint64))
+	assert.Equal(t, uid, s.Uid)
+	assert.Equal(t, gid, s.Gid)
+	assert.Equal(t, uint32(fuse.S_IFREG|0640), s.Mode)
+
+	stats := make(map[string]fuse.Stat_t)
+	require.Equal(t, 0, f.Readdir("/", func(name string, stat *fuse.Stat_t, _ int64) bool {
+		stats[name] = *stat
+		return true
+	}, 0, math.MaxUint64))
+	require.Len(t, stats, 2)
+	assert.Equal(t, uid, stats["sub"].Uid)
+	assert.Equal(t, gid, stats["sub"].Gid)
+	assert.Equal(t, uint32(fuse.S_IFDIR|0750), stats["sub"].Mode)
+	assert.Equal(t, uint32(fuse.S_IFREG|0640), stats["test1.txt"].Mode)
+
+	// The facts of the raw listing are available
+	conn, err := f.pool.get(f.ctx)
+	require.NoError(t, err)
+	defer f.pool.put(conn)
+	var es []*entry
+	require.NoError(t, conn.list("", func(e *entry) { es = append(es, e) }))
+	require.Len(t, es, 2)
+	for _, e := range es {
+		assert.NotEmpty(t, e.facts["type"], e.Name)
+	}
+	e, err := conn.stat("test1.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "file", e.facts["type"])
+	assert.Equal(t, "10", e.facts["size"])
+}
@@ -0,0 +1,213 @@
+package fs
+
+import (
+	"container/list"
+	"path"
+	"strings"
+	"sync"
+)
+
+// rootIno is the inode number of the root directory.
+const rootIno = 1
+
+// maxInodePaths is the maximum number of paths that the inodeTable remembers. The paths that
+// were used least recently are forgotten first.
+const maxInodePaths = 1 << 17
+
+// inode is an entry in the inodeTable. The links are the number of paths that refer to it.
+type inode struct {
+	ino    uint64
+	unique string
+	links  int
+}
+
+// inodePath is a path in the inodeTable, and its element in the list of recently used paths.
+type inodePath struct {
+	path string
+	n    *inode
+	elem *list.Element
+}
+
+// inodeTable assigns stable inode numbers to paths. When the FTP server reports a "unique" fact,
+// the number follows that fact, so that hard links share a number and a file keeps its number
+// when it is renamed by someone else. Otherwise, the number follows the path, and is moved when
+// the path is renamed using the file system. The table remembers at most maxInodePaths paths,
+// so a path that hasn't been used for a long time may get a new number. The zero value is ready
+// to use.
+type inodeTable struct {
+	sync.Mutex
+	next     uint64
+	byPath   map[string]*inodePath
+	byUnique map[string]*inode
+
+	// children maps directories to the paths in them that the table has, so that renames and
+	// removals only visit the paths that they affect.
+	children map[string]map[string]struct{}
+
+	// recent has the paths, most recently used first.
+	recent list.List
+
+	// max is the maximum number of paths. Zero means maxInodePaths.
+	max int
+}
+
+// ino returns the inode number of the given path, which has the given unique fact, or an empty
+// unique when the server reports none.
+func (t *inodeTable) ino(path, unique string) uint64 {
+	if path == "/" {
+		return rootIno
+	}
+	t.Lock()
+	defer t.Unlock()
+	if t.byPath == nil {
+		t.byPath = make(map[string]*inodePath)
+		t.byUnique = make(map[string]*inode)
+		t.children = make(map[string]map[string]struct{})
+	}
+	if unique != "" {
+		if n, ok := t.byUnique[unique]; ok {
+			t.setPath(path, n)
+			return n.ino
+		}
+	} else if ip, ok := t.byPath[path]; ok && ip.n.unique == "" {
+		t.recent.MoveToFront(ip.elem)
+		return ip.n.ino
+	}
+	// A new file, or a path that now refers to a file with another unique fact.
+	if t.next <= rootIno {
+		t.next = rootIno + 1
+	}
+	n := &inode{ino: t.next, unique: unique}
+	t.next++
+	t.setPath(path, n)
+	if unique != "" {
+		t.byUnique[unique] = n
+	}
+	return n.ino
+}
+
+// rename moves the numbers of the given path, and of everything below it, to the new path.
+// The numbers of the paths that are replaced are forgotten.
+func (t *inodeTable) rename(oldpath, newpath string) {
+	t.Lock()
+	defer t.Unlock()
+	t.removeLocked(newpath)
+	var moved []*inodePath
+	t.walk(oldpath, func(ip *inodePath) {
+		moved = append(moved, ip)
+	})
+	for _, ip := range moved {
+		t.unlinkPath(ip)
+	}
+	for _, ip := range moved {
+		ip.path = newpath + strings.TrimPrefix(ip.path, oldpath)
+		t.linkPath(ip)
+	}
+}
+
+// remove forgets the numbers of the given path and of everything below it.
+func (t *inodeTable) remove(path string) {
+	t.Lock()
+	t.removeLocked(path)
+	t.Unlock()
+}
+
+func (t *inodeTable) removeLocked(path string) {
+	var removed []*inodePath
+	t.walk(path, func(ip *inodePath) {
+		removed = append(removed, ip)
+	})
+	for _, ip := range removed {
+		t.deletePath(ip.path)
+	}
+}
+
+// walk calls fn for the given path and for each path below it that the table has. The table
+// must be locked.
+func (t *inodeTable) walk(p string, fn func(*inodePath)) {
+	if ip, ok := t.byPath[p]; ok {
+		fn(ip)
+	}
+	for c := range t.children[p] {
+		t.walk(c, fn)
+	}
+}
+
+// setPath makes the path refer to the given inode, and forgets the least recently used path
+// when the table is full. The table must be locked.
+func (t *inodeTable) setPath(path string, n *inode) {
+	if ip, ok := t.byPath[path]; ok {
+		if ip.n == n {
+			t.recent.MoveToFront(ip.elem)
+			return
+		}
+		t.deletePath(path)
+	}
+	max := t.max
+	if max <= 0 {
+		max = maxInodePaths
+	}
+	for len(t.byPath) >= max {
+		t.deletePath(t.recent.Back().Value.(*inodePath).path)
+	}
+	t.linkPath(&inodePath{path: path, n: n})
+	n.links++
+}
+
+// linkPath adds the given path to the maps and to the front of the recently used paths. The
+// table must be locked.
+func (t *inodeTable) linkPath(ip *inodePath) {
+	t.byPath[ip.path] = ip
+	ip.elem = t.recent.PushFront(ip)
+	dir := path.Dir(ip.path)
+	cs, ok := t.children[dir]
+	if !ok {
+		cs = make(map[string]struct{})
+		t.children[dir] = cs
+	}
+	cs[ip.path] = struct{}{}
+}
+
+// unlinkPath removes the given path from the maps and from the recently used paths. The table
+// must be locked.
+func (t *inodeTable) unlinkPath(ip *inodePath) {
+	delete(t.byPath, ip.path)
+	t.recent.Remove(ip.elem)
+	dir := path.Dir(ip.path)
+	if cs, ok := t.children[dir]; ok {
+		delete(cs, ip.path)
+		if len(cs) == 0 {
+			delete(t.children, dir)
+		}
+	}
+}
+
+// deletePath removes the path, and forgets the unique fact of its inode when no other path
+// refers to it. The table must be locked.
+func (t *inodeTable) deletePath(path string) {
+	ip, ok := t.byPath[path]
+	if !ok {
+		return
+	}
+	t.unlinkPath(ip)
+	n := ip.n
+	n.links--
+	if n.links == 0 && n.unique != "" && t.byUnique[n.unique] == n {
+		delete(t.byUnique, n.unique)
+	}
+}
+
+// reset forgets all numbers. Numbers that have been handed out are never reused.
+func (t *inodeTable) reset() {
+	t.Lock()
+	t.byPath = nil
+	t.byUnique = nil
+	t.children = nil
+	t.recent.Init()
+	t.Unlock()
+}
+
+// joinPath returns the path of the given name in the given directory.
+func joinPath(dir, name string) string {
+	return strings.TrimSuffix(dir, "/") + "/" + name
+}
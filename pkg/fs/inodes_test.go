@@ -0,0 +1,139 @@
+package fs
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestInodeTable(t *testing.T) {
+	var it inodeTable
+	assert.Equal(t, uint64(rootIno), it.ino("/", ""))
+
+	a := it.ino("/a", "")
+	d := it.ino("/d", "")
+	da := it.ino("/d/a", "")
+	assert.NotEqual(t, a, d)
+	assert.NotEqual(t, d, da)
+	assert.Equal(t, a, it.ino("/a", ""))
+
+	// Renames move the numbers of the path and everything below it
+	it.rename("/d", "/e")
+	assert.Equal(t, d, it.ino("/e", ""))
+	assert.Equal(t, da, it.ino("/e/a", ""))
+	assert.NotEqual(t, d, it.ino("/d", ""))
+
+	// The number of a replaced path is forgotten
+	it.rename("/a", "/e/a")
+	assert.Equal(t, a, it.ino("/e/a", ""))
+
+	it.remove("/e")
+	assert.NotEqual(t, d, it.ino("/e", ""))
+	assert.NotEqual(t, a, it.ino("/e/a", ""))
+
+	// Numbers follow the unique fact, so hard links share a number
+	u := it.ino("/x", "u1")
+	assert.Equal(t, u, it.ino("/y", "u1"))
+	it.remove("/x")
+	assert.Equal(t, u, it.ino("/y", "u1"))
+	it.remove("/y")
+	assert.NotEqual(t, u, it.ino("/y", "u1"))
+
+	// A path that refers to another file gets a new number
+	v := it.ino("/z", "u2")
+	assert.NotEqual(t, v, it.ino("/z", "u3"))
+
+	// Renames leave paths that share a prefix alone
+	dd := it.ino("/dd", "")
+	it.rename("/d", "/f")
+	assert.Equal(t, dd, it.ino("/dd", ""))
+
+	// Numbers are never reused
+	it.reset()
+	assert.Greater(t, it.ino("/a", ""), v)
+}
+
+func TestInodeTableLimit(t *testing.T) {
+	it := inodeTable{max: 3}
+	a := it.ino("/a", "")
+	b := it.ino("/d/b", "")
+	c := it.ino("/d/c", "")
+
+	// The least recently used path is forgotten first
+	assert.Equal(t, a, it.ino("/a", ""))
+	it.ino("/e", "")
+	assert.Len(t, it.byPath, 3)
+	assert.Equal(t, a, it.ino("/a", ""))
+	assert.Equal(t, c, it.ino("/d/c", ""))
+	assert.NotEqual(t, b, it.ino("/d/b", ""))
+	assert.Len(t, it.byPath, 3)
+
+	// Forgotten paths leave no trace in the index of the directories
+	it.remove("/d")
+	it.remove("/a")
+	it.remove("/e")
+	assert.Empty(t, it.byPath)
+	assert.Empty(t, it.children)
+	assert.Zero(t, it.recent.Len())
+}
+
+func TestInodes(t *testing.T) {
+	f, root := newTestClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), []byte("Some text\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "test2.txt"), []byte("More text\n"), 0644))
+
+	getIno := func(path string) uint64 {
+		s := &fuse.Stat_t{}
+		require.Equal(t, 0, f.Getattr(path, s, math.MaxUint64))
+		return s.Ino
+	}
+	readdirInos := func(path string) map[string]uint64 {
+		inos := make(map[string]uint64)
+		require.Equal(t, 0, f.Readdir(path, func(name string, s *fuse.Stat_t, _ int64) bool {
+			inos[name] = s.Ino
+			return true
+		}, 0, math.MaxUint64))
+		return inos
+	}
+
+	assert.Equal(t, uint64(rootIno), getIno("/"))
+	test1 := getIno("/test1.txt")
+	sub := getIno("/sub")
+	assert.NotZero(t, test1)
+	assert.NotEqual(t, test1, sub)
+	assert.Equal(t, map[string]uint64{"test1.txt": test1, "sub": sub}, readdirInos("/"))
+	test2 := readdirInos("/sub")["test2.txt"]
+	assert.Equal(t, test2, getIno("/sub/test2.txt"))
+
+	// The numbers survive renames
+	require.Equal(t, 0, f.Rename("/sub", "/moved"))
+	assert.Equal(t, sub, getIno("/moved"))
+	assert.Equal(t, test2, getIno("/moved/test2.txt"))
+	require.Equal(t, 0, f.Rename("/test1.txt", "/moved/test1.txt"))
+	assert.Equal(t, map[string]uint64{"test1.txt": test1, "test2.txt": test2}, readdirInos("/moved"))
+
+	require.Equal(t, 0, f.Unlink("/moved/test1.txt"))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "moved", "test1.txt"), []byte("New text\n"), 0644))
+	assert.NotEqual(t, test1, getIno("/moved/test1.txt"))
+}
+
+func TestOpenEntry(t *testing.T) {
+	fe := &info{path: "/a", entry: entry{Entry: ftp.Entry{Name: "a", Size: 42}, facts: map[string]string{"unique": "u1"}}}
+	f := &fuseImpl{current: map[uint64]*info{1: fe}}
+	assert.Same(t, &fe.entry, f.openEntry("/a", ""))
+	assert.Nil(t, f.openEntry("/b", ""))
+	assert.Nil(t, f.openEntry("/b", "u2"))
+
+	// A hard link of an open file gets the entry of its handle
+	e := f.openEntry("/b", "u1")
+	require.NotNil(t, e)
+	assert.Equal(t, "b", e.Name)
+	assert.Equal(t, uint64(42), e.Size)
+}
@@ -0,0 +1,88 @@
+package fs
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// errInterrupted is returned by operations that were aborted because the process that
+// made the FUSE call was interrupted.
+var errInterrupted = errors.New("operation interrupted")
+
+// interruptPollInterval is the interval used when checking if the process that made a
+// FUSE call has been interrupted.
+const interruptPollInterval = 100 * time.Millisecond
+
+// callerPID returns the process id of the process that made the current FUSE call, or
+// zero when that is unknown. The FUSE context is only valid when the file system is mounted
+// and the call originates from the FUSE host.
+func (f *fuseImpl) callerPID() int {
+	if !f.mounted.Load() {
+		return 0
+	}
+	_, _, pid := getcontext()
+	return pid
+}
+
+// interruptible calls fn while watching the process that made the current FUSE call. If that
+// process is interrupted before fn returns, the given connection is aborted, which causes
+// fn to return promptly, and errInterrupted is returned. The aborted connection must then
+// be returned to the pool, which resets it, or replaces it with a new one if that fails.
+func (f *fuseImpl) interruptible(conn *ftpConn, fn func() error) error {
+	stop := f.watchCaller(conn.abort)
+	err := fn()
+	if stop() {
+		return errInterrupted
+	}
+	return err
+}
+
+// watchCaller calls onInterrupt if the process that made the current FUSE call is interrupted
+// before the returned stop function is called. The stop function reports whether onInterrupt
+// was called.
+//
+// cgofuse doesn't pass the interrupt requests of the kernel on to the file system, so the
+// process is polled instead, and only an interruption by a fatal signal can be detected. A
+// process that handles the signal that interrupts it waits for the call to complete. The
+// polling is only implemented on Linux. Interrupts are not detected on other platforms, where
+// an interrupted process waits for the call to complete.
+func (f *fuseImpl) watchCaller(onInterrupt func()) (stop func() bool) {
+	if !interruptsDetected {
+		return func() bool { return false }
+	}
+	pid := f.callerPID()
+	if pid <= 0 {
+		return func() bool { return false }
+	}
+	// A timer, rather than a goroutine, waits for the next poll, so that calls that complete
+	// before the first poll are cheap.
+	var (
+		mu          sync.Mutex
+		done        bool
+		interrupted bool
+		timer       *time.Timer
+	)
+	mu.Lock()
+	timer = time.AfterFunc(interruptPollInterval, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return
+		}
+		if isInterrupted(pid) {
+			interrupted = true
+			onInterrupt()
+			return
+		}
+		timer.Reset(interruptPollInterval)
+	})
+	mu.Unlock()
+	return func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		done = true
+		timer.Stop()
+		return interrupted
+	}
+}
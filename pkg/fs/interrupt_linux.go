@@ -0,0 +1,72 @@
+package fs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// interruptsDetected is true on platforms where isInterrupted works.
+const interruptsDetected = true
+
+// sigMask returns the mask of the given signals, as used in /proc/<pid>/status.
+func sigMask(sigs ...unix.Signal) uint64 {
+	var m uint64
+	for _, s := range sigs {
+		m |= uint64(1) << (s - 1)
+	}
+	return m
+}
+
+// nonFatalSignals are the signals whose default action doesn't terminate the process.
+var nonFatalSignals = sigMask(unix.SIGCHLD, unix.SIGCONT, unix.SIGSTOP, unix.SIGTSTP, unix.SIGTTIN, unix.SIGTTOU, unix.SIGURG, unix.SIGWINCH)
+
+// isInterrupted returns true if the process with the given id is gone or about to be
+// killed.
+func isInterrupted(pid int) bool {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return errors.Is(err, fs.ErrNotExist)
+	}
+	return fatalPending(data)
+}
+
+// fatalPending returns true if the given content of a /proc/<pid>/status file shows a pending
+// signal that will terminate the process. That is SIGKILL, which the kernel adds to the pending
+// signals of all threads of a process that receives a fatal signal, or any other pending signal
+// that is neither blocked, ignored, nor caught, and whose default action is to terminate.
+func fatalPending(status []byte) bool {
+	var pending, blocked, ignored, caught uint64
+	sc := bufio.NewScanner(bytes.NewReader(status))
+	for sc.Scan() {
+		k, v, ok := strings.Cut(sc.Text(), ":")
+		if !ok {
+			continue
+		}
+		var dst *uint64
+		switch k {
+		case "SigPnd", "ShdPnd":
+			dst = &pending
+		case "SigBlk":
+			dst = &blocked
+		case "SigIgn":
+			dst = &ignored
+		case "SigCgt":
+			dst = &caught
+		default:
+			continue
+		}
+		if mask, err := strconv.ParseUint(strings.TrimSpace(v), 16, 64); err == nil {
+			*dst |= mask
+		}
+	}
+	sigKill := sigMask(unix.SIGKILL)
+	return pending&sigKill != 0 || pending&^(blocked|ignored|caught|nonFatalSignals) != 0
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package fs
+
+// interruptsDetected is true on platforms where isInterrupted works.
+const interruptsDetected = false
+
+// isInterrupted always returns false, because detection of interrupted processes is only
+// supported on Linux.
+func isInterrupted(_ int) bool {
+	return false
+}
@@ -0,0 +1,166 @@
+package fs
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbort(t *testing.T) {
+	addr := startHangingServer(t)
+	p := &connPool{timeouts: Timeouts{Control: time.Minute}, dialer: &net.Dialer{}}
+	require.NoError(t, p.setAddr(addr))
+	t.Cleanup(p.quit)
+
+	conn, err := p.get(context.Background())
+	require.NoError(t, err)
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := conn.GetEntry("somefile.txt")
+		errCh <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	conn.abort()
+	select {
+	case err = <-errCh:
+		assert.Error(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("abort did not interrupt the command")
+	}
+
+	// The aborted connection must be replaced by a new idle connection
+	p.put(conn)
+	assert.Eventually(t, func() bool {
+		p.Lock()
+		defer p.Unlock()
+		idle := p.idleList.conns()
+		return len(idle) == 1 && idle[0] != conn && p.busyList == nil
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestAbortTransfer(t *testing.T) {
+	root, addr := startTestServer(t)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "big.bin"), make([]byte, 64<<20), 0644))
+	p := &connPool{dir: remoteDir, timeouts: Timeouts{}.withDefaults(5 * time.Second), dialer: &net.Dialer{}}
+	require.NoError(t, p.setAddr(addr))
+	t.Cleanup(p.quit)
+
+	conn, err := p.get(context.Background())
+	require.NoError(t, err)
+	rr, err := conn.Retr("big.bin")
+	require.NoError(t, err)
+	_, err = io.ReadFull(rr, make([]byte, 4096))
+	require.NoError(t, err)
+
+	// Closing the data connection ends the transfer, and the server replies to the RETR
+	conn.abort()
+	_, err = io.Copy(io.Discard, rr)
+	assert.Error(t, err)
+	_ = rr.Close()
+	assert.False(t, conn.broken.Load())
+
+	// The connection is reset using ABOR and returned to the pool
+	p.put(conn)
+	assert.Eventually(t, func() bool {
+		p.Lock()
+		defer p.Unlock()
+		idle := p.idleList.conns()
+		return len(idle) == 1 && idle[0] == conn && p.busyList == nil
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.False(t, conn.aborted.Load())
+
+	conn, err = p.get(context.Background())
+	require.NoError(t, err)
+	defer p.put(conn)
+	es, err := conn.List("")
+	require.NoError(t, err)
+	require.Len(t, es, 1)
+	assert.Equal(t, "big.bin", es[0].Name)
+}
+
+func TestFatalPending(t *testing.T) {
+	if !interruptsDetected {
+		t.Skip("interrupted processes are only detected on Linux")
+	}
+	status := func(pnd, blk, ign, cgt string) []byte {
+		return []byte("Name:\tcat\nShdPnd:\t0000000000000000\nSigPnd:\t" + pnd + "\nSigBlk:\t" + blk +
+			"\nSigIgn:\t" + ign + "\nSigCgt:\t" + cgt + "\n")
+	}
+	tests := []struct {
+		name   string
+		status []byte
+		want   bool
+	}{
+		{"none", status("0000000000000000", "0000000000000000", "0000000000000000", "0000000000000000"), false},
+		{"SIGKILL", status("0000000000000100", "0000000000000000", "0000000000000000", "0000000000000000"), true},
+		{"SIGTERM", status("0000000000004000", "0000000000000000", "0000000000000000", "0000000000000000"), true},
+		{"SIGINT", status("0000000000000002", "0000000000000000", "0000000000000000", "0000000000000000"), true},
+		{"SIGINT caught", status("0000000000000002", "0000000000000000", "0000000000000000", "0000000000000002"), false},
+		{"SIGINT ignored", status("0000000000000002", "0000000000000000", "0000000000000002", "0000000000000000"), false},
+		{"SIGINT blocked", status("0000000000000002", "0000000000000002", "0000000000000000", "0000000000000000"), false},
+		{"SIGKILL blocked", status("0000000000000100", "0000000000000100", "0000000000000000", "0000000000000000"), true},
+		{"SIGCHLD", status("0000000000010000", "0000000000000000", "0000000000000000", "0000000000000000"), false},
+		{"SIGWINCH", status("0000000008000000", "0000000000000000", "0000000000000000", "0000000000000000"), false},
+		{"shared SIGKILL", []byte("ShdPnd:\t0000000000000100\nSigPnd:\t0000000000000000\n"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fatalPending(tt.status))
+		})
+	}
+}
+
+func TestInterruptible(t *testing.T) {
+	if !interruptsDetected {
+		t.Skip("interrupted processes are only detected on Linux")
+	}
+	addr := startHangingServer(t)
+	p := &connPool{timeouts: Timeouts{Control: time.Minute}, dialer: &net.Dialer{}}
+	require.NoError(t, p.setAddr(addr))
+	t.Cleanup(p.quit)
+	conn, err := p.get(context.Background())
+	require.NoError(t, err)
+	defer p.put(conn)
+
+	// A process that has exited is the same as one that has been killed
+	cmd := exec.Command("true")
+	require.NoError(t, cmd.Run())
+	pid := os.Getpid()
+	orig := getcontext
+	getcontext = func() (uint32, uint32, int) { return 0, 0, pid }
+	t.Cleanup(func() { getcontext = orig })
+	f := &fuseImpl{}
+	f.mounted.Store(true)
+
+	// Calls made by a live process complete, and leave the connection alone
+	assert.NoError(t, f.interruptible(conn, func() error {
+		time.Sleep(3 * interruptPollInterval)
+		return nil
+	}))
+	assert.False(t, conn.aborted.Load())
+
+	pid = cmd.Process.Pid
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- f.interruptible(conn, func() error {
+			_, err := conn.GetEntry("somefile.txt")
+			return err
+		})
+	}()
+	select {
+	case err = <-errCh:
+		assert.ErrorIs(t, err, errInterrupted)
+		assert.True(t, conn.aborted.Load())
+	case <-time.After(2 * time.Second):
+		t.Fatal("the interrupted call did not return")
+	}
+}
@@ -0,0 +1,35 @@
+package fs
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// WithLogger sets the logger that is used by the FTP client and by the FuseHost that serves it.
+// Using a logger with its own logrus.Logger makes it possible to control the log level of each
+// client individually, and fields added to the entry, such as a mount id, are included in all
+// messages. The standard logger is used by default.
+func WithLogger(l *logrus.Entry) Option {
+	return func(f *fuseImpl) {
+		f.log = l
+		f.pool.log = l
+	}
+}
+
+// loggerOwner is implemented by file systems that provide the logger used by the FuseHost.
+type loggerOwner interface {
+	logger() *logrus.Entry
+}
+
+func (f *fuseImpl) logger() *logrus.Entry {
+	if f.log == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	return f.log
+}
+
+func (p *connPool) logger() *logrus.Entry {
+	if p.log == nil {
+		return logrus.NewEntry(logrus.StandardLogger())
+	}
+	return p.log
+}
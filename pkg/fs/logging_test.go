@@ -0,0 +1,32 @@
+package fs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestLogger(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.InfoLevel)
+	f, _ := newTestClient(t, WithLogger(logger.WithField("mount_id", 3)))
+
+	// Debug messages are only logged when the level of the client's logger allows it.
+	stat := &fuse.Stat_t{}
+	f.Getattr("/", stat, 0)
+	assert.Empty(t, hook.AllEntries())
+	logger.SetLevel(logrus.DebugLevel)
+	f.Getattr("/", stat, 0)
+	require.NotEmpty(t, hook.AllEntries())
+	assert.Equal(t, 3, hook.LastEntry().Data["mount_id"])
+
+	f.errToFuseErr(errors.New("boom"))
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, 3, hook.LastEntry().Data["mount_id"])
+	assert.Same(t, f.log, NewHost(f, "").log)
+}
@@ -0,0 +1,221 @@
+package fs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MountOptions are the options that a FuseHost passes to FUSE when mounting the file system.
+// The zero value mounts the file system so that only the user that mounts it has access. Use
+// DefaultMountOptions to also give root access.
+type MountOptions struct {
+	// AllowOther gives all users access to the mount. AllowRoot gives access to root in
+	// addition to the user that mounts it. On Linux, both require "user_allow_other" in
+	// /etc/fuse.conf unless the mount is made by root. They cannot be combined.
+	AllowOther bool
+	AllowRoot  bool
+
+	// UID and GID override the owner of all files. Umask overrides the permission bits
+	// that are cleared. A nil value means no override.
+	UID   *uint32
+	GID   *uint32
+	Umask *uint32
+
+	// FSName is the name of the file system, shown as the source of the mount. Subtype is
+	// shown as the part after "fuse." in the file system type.
+	FSName  string
+	Subtype string
+
+	// MaxRead is the maximum size of a read request. Zero means the FUSE default.
+	MaxRead uint32
+
+	// EntryTimeout, AttrTimeout, and NegativeTimeout are the times that the kernel caches
+	// names, attributes, and failed lookups. A nil value means the FUSE default.
+	EntryTimeout    *time.Duration
+	AttrTimeout     *time.Duration
+	NegativeTimeout *time.Duration
+
+	// Extra contains additional options, in the "name" or "name=value" form, that are passed
+	// using "-o". Options that have a field in MountOptions, or that the FuseHost sets by
+	// itself, are rejected.
+	Extra []string
+}
+
+// DefaultMountOptions returns the options that NewHost uses. They give root access to the mount
+// in addition to the user that mounts it, except on Windows, where WinFsp controls access.
+func DefaultMountOptions() MountOptions {
+	return MountOptions{AllowRoot: runtime.GOOS != "windows"}
+}
+
+// fuseConfPath is the file that must permit allow_other and allow_root for non-root users.
+var fuseConfPath = "/etc/fuse.conf"
+
+// managedOptions are the options that cannot be given using MountOptions.Extra, mapped to the
+// field or setting to use instead.
+var managedOptions = map[string]string{
+	"allow_other":         "AllowOther",
+	"allow_root":          "AllowRoot",
+	"uid":                 "UID",
+	"gid":                 "GID",
+	"umask":               "Umask",
+	"fsname":              "FSName",
+	"subtype":             "Subtype",
+	"max_read":            "MaxRead",
+	"entry_timeout":       "EntryTimeout",
+	"attr_timeout":        "AttrTimeout",
+	"negative_timeout":    "NegativeTimeout",
+	"ro":                  "WithReadOnly",
+	"rw":                  "WithReadOnly",
+	"debug":               "the debug log level",
+	"default_permissions": "the defaults",
+	"use_ino":             "the defaults",
+}
+
+// Validate returns an error when the options are invalid or unsupported on this platform.
+func (o *MountOptions) Validate() error {
+	_, err := o.args(runtime.GOOS)
+	if err == nil && (o.AllowOther || o.AllowRoot) && runtime.GOOS == "linux" {
+		err = checkAllowOther(fuseConfPath, os.Geteuid())
+	}
+	return err
+}
+
+// args returns the "-o" arguments for the options on the given platform.
+func (o *MountOptions) args(goos string) ([]string, error) {
+	var opts []string
+	add := func(opt string) {
+		opts = append(opts, "-o", opt)
+	}
+	unsupported := func(name string) error {
+		return fmt.Errorf("the %s mount option is not supported on %s", name, goos)
+	}
+
+	if o.AllowOther && o.AllowRoot {
+		return nil, errors.New("the allow_other and allow_root mount options cannot be combined")
+	}
+	if goos == "windows" {
+		// WinFsp controls access using the uid, gid, and umask options.
+		switch {
+		case o.AllowOther:
+			return nil, unsupported("allow_other")
+		case o.AllowRoot:
+			return nil, unsupported("allow_root")
+		case o.FSName != "":
+			return nil, unsupported("fsname")
+		case o.Subtype != "":
+			return nil, unsupported("subtype")
+		case o.MaxRead != 0:
+			return nil, unsupported("max_read")
+		case o.EntryTimeout != nil:
+			return nil, unsupported("entry_timeout")
+		case o.AttrTimeout != nil:
+			return nil, unsupported("attr_timeout")
+		case o.NegativeTimeout != nil:
+			return nil, unsupported("negative_timeout")
+		}
+	}
+	if goos == "darwin" && o.Subtype != "" {
+		return nil, unsupported("subtype")
+	}
+	if o.AllowOther {
+		add("allow_other")
+	}
+	if o.AllowRoot {
+		add("allow_root")
+	}
+
+	uid, gid := o.UID, o.GID
+	if goos == "windows" {
+		// WinFsp requires this to create files with the same
+		// user as the one that starts the FUSE mount
+		if uid == nil {
+			add("uid=-1")
+		}
+		if gid == nil {
+			add("gid=-1")
+		}
+	}
+	if uid != nil {
+		add("uid=" + strconv.FormatUint(uint64(*uid), 10))
+	}
+	if gid != nil {
+		add("gid=" + strconv.FormatUint(uint64(*gid), 10))
+	}
+	if o.Umask != nil {
+		if *o.Umask > 0777 {
+			return nil, fmt.Errorf("invalid umask %#o", *o.Umask)
+		}
+		add(fmt.Sprintf("umask=%03o", *o.Umask))
+	}
+
+	for _, s := range []struct{ name, value string }{{"fsname", o.FSName}, {"subtype", o.Subtype}} {
+		if s.value == "" {
+			continue
+		}
+		if strings.ContainsAny(s.value, ", \t\n") {
+			return nil, fmt.Errorf("invalid %s %q: must not contain commas or whitespace", s.name, s.value)
+		}
+		add(s.name + "=" + s.value)
+	}
+	if o.MaxRead != 0 {
+		add("max_read=" + strconv.FormatUint(uint64(o.MaxRead), 10))
+	}
+
+	for _, t := range []struct {
+		name  string
+		value *time.Duration
+	}{{"entry_timeout", o.EntryTimeout}, {"attr_timeout", o.AttrTimeout}, {"negative_timeout", o.NegativeTimeout}} {
+		if t.value == nil {
+			continue
+		}
+		if *t.value < 0 {
+			return nil, fmt.Errorf("invalid %s %s: must not be negative", t.name, *t.value)
+		}
+		add(t.name + "=" + strconv.FormatFloat(t.value.Seconds(), 'f', -1, 64))
+	}
+
+	for _, e := range o.Extra {
+		name, _, _ := strings.Cut(e, "=")
+		if name == "" || strings.ContainsAny(e, ", \t\n") {
+			return nil, fmt.Errorf("invalid mount option %q", e)
+		}
+		if use, ok := managedOptions[name]; ok {
+			return nil, fmt.Errorf("the %s mount option cannot be given as an extra option, use %s instead", name, use)
+		}
+		add(e)
+	}
+	return opts, nil
+}
+
+// checkAllowOther returns an error unless the given user may use allow_other and allow_root
+// according to the given fuse.conf file.
+func checkAllowOther(confPath string, euid int) error {
+	if euid == 0 {
+		return nil
+	}
+	errNotAllowed := fmt.Errorf(`the allow_other and allow_root mount options require "user_allow_other" in %s when not mounting as root`, confPath)
+	f, err := os.Open(confPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errNotAllowed
+		}
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if strings.TrimSpace(sc.Text()) == "user_allow_other" {
+			return nil
+		}
+	}
+	if err = sc.Err(); err != nil {
+		return err
+	}
+	return errNotAllowed
+}
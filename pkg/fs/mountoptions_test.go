@@ -0,0 +1,152 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMountOptions(t *testing.T) {
+	u32 := func(v uint32) *uint32 { return &v }
+	dur := func(v time.Duration) *time.Duration { return &v }
+
+	tests := []struct {
+		name    string
+		goos    string
+		options MountOptions
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "default",
+			goos: "linux",
+		},
+		{
+			name: "default windows",
+			goos: "windows",
+			want: []string{"-o", "uid=-1", "-o", "gid=-1"},
+		},
+		{
+			name: "all",
+			goos: "linux",
+			options: MountOptions{
+				AllowOther:      true,
+				UID:             u32(1000),
+				GID:             u32(0),
+				Umask:           u32(022),
+				FSName:          "ftp",
+				Subtype:         "fuseftp",
+				MaxRead:         131072,
+				EntryTimeout:    dur(1500 * time.Millisecond),
+				AttrTimeout:     dur(0),
+				NegativeTimeout: dur(time.Minute),
+				Extra:           []string{"noatime", "max_background=32"},
+			},
+			want: []string{
+				"-o", "allow_other",
+				"-o", "uid=1000",
+				"-o", "gid=0",
+				"-o", "umask=022",
+				"-o", "fsname=ftp",
+				"-o", "subtype=fuseftp",
+				"-o", "max_read=131072",
+				"-o", "entry_timeout=1.5",
+				"-o", "attr_timeout=0",
+				"-o", "negative_timeout=60",
+				"-o", "noatime",
+				"-o", "max_background=32",
+			},
+		},
+		{
+			name:    "uid on windows",
+			goos:    "windows",
+			options: MountOptions{UID: u32(1000), Umask: u32(077)},
+			want:    []string{"-o", "gid=-1", "-o", "uid=1000", "-o", "umask=077"},
+		},
+		{
+			name:    "allow_other and allow_root",
+			goos:    "linux",
+			options: MountOptions{AllowOther: true, AllowRoot: true},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "allow_root on windows",
+			goos:    "windows",
+			options: MountOptions{AllowRoot: true},
+			wantErr: "the allow_root mount option is not supported on windows",
+		},
+		{
+			name:    "subtype on darwin",
+			goos:    "darwin",
+			options: MountOptions{Subtype: "fuseftp"},
+			wantErr: "the subtype mount option is not supported on darwin",
+		},
+		{
+			name:    "umask",
+			goos:    "linux",
+			options: MountOptions{Umask: u32(01000)},
+			wantErr: "invalid umask",
+		},
+		{
+			name:    "fsname",
+			goos:    "linux",
+			options: MountOptions{FSName: "a,b"},
+			wantErr: "invalid fsname",
+		},
+		{
+			name:    "negative timeout",
+			goos:    "linux",
+			options: MountOptions{AttrTimeout: dur(-time.Second)},
+			wantErr: "invalid attr_timeout",
+		},
+		{
+			name:    "empty extra",
+			goos:    "linux",
+			options: MountOptions{Extra: []string{""}},
+			wantErr: "invalid mount option",
+		},
+		{
+			name:    "managed extra",
+			goos:    "linux",
+			options: MountOptions{Extra: []string{"uid=0"}},
+			wantErr: "use UID instead",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.options.args(tt.goos)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// NewHost gives root access, like it always has
+	do := DefaultMountOptions()
+	_, err := do.args(runtime.GOOS)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.GOOS != "windows", do.AllowRoot)
+	assert.Equal(t, do, NewHost(nil, "").options)
+}
+
+func TestCheckAllowOther(t *testing.T) {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "fuse.conf")
+	assert.NoError(t, checkAllowOther(conf, 0))
+	assert.Error(t, checkAllowOther(conf, 1000))
+
+	require.NoError(t, os.WriteFile(conf, []byte("# user_allow_other\nmount_max = 1000\n"), 0644))
+	assert.Error(t, checkAllowOther(conf, 1000))
+
+	require.NoError(t, os.WriteFile(conf, []byte("mount_max = 1000\nuser_allow_other\n"), 0644))
+	assert.NoError(t, checkAllowOther(conf, 1000))
+}
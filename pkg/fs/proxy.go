@@ -0,0 +1,177 @@
+package fs
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// WithProxy makes the FTP client dial both control and data connections through the
+// proxy at the given URL. Supported schemes are socks5, socks5h, and http (using the
+// CONNECT method). When no proxy is given, the FTP_PROXY and ALL_PROXY environment
+// variables are consulted, and hosts listed in NO_PROXY are dialed directly.
+func WithProxy(proxyURL string) Option {
+	return func(f *fuseImpl) {
+		f.pool.proxyURL = proxyURL
+	}
+}
+
+// proxyFromEnvironment returns the first non-empty value of the environment
+// variables that can be used to declare an FTP proxy.
+func proxyFromEnvironment() string {
+	for _, ev := range []string{"FTP_PROXY", "ftp_proxy", "ALL_PROXY", "all_proxy"} {
+		if v := os.Getenv(ev); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// newDialer creates the dialer used by the connPool. The dialer is direct unless a
+// proxyURL is given or declared in the environment.
+func newDialer(proxyURL string) (proxy.ContextDialer, error) {
+	direct := &net.Dialer{}
+	fromEnv := proxyURL == ""
+	if fromEnv {
+		if proxyURL = proxyFromEnvironment(); proxyURL == "" {
+			return direct, nil
+		}
+	}
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", proxyURL, err)
+	}
+
+	var pd proxy.Dialer
+	switch u.Scheme {
+	case "socks5", "socks5h":
+		if pd, err = proxy.FromURL(u, direct); err != nil {
+			return nil, err
+		}
+	case "http":
+		pd = &httpConnectDialer{proxyURL: u, forward: direct}
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+	pcd := &proxiedDialer{dialer: pd.(proxy.ContextDialer)}
+	if fromEnv {
+		noProxy := os.Getenv("NO_PROXY")
+		if noProxy == "" {
+			noProxy = os.Getenv("no_proxy")
+		}
+		if noProxy != "" {
+			ph := proxy.NewPerHost(pcd, direct)
+			ph.AddFromString(noProxy)
+			return ph, nil
+		}
+	}
+	return pcd, nil
+}
+
+// proxiedDialer ensures that connections dialed through a proxy report the address of
+// the FTP server as their remote address. The ftp.ServerConn uses the remote address
+// of the control connection as the host when dialing data connections in passive mode.
+type proxiedDialer struct {
+	dialer proxy.ContextDialer
+}
+
+type proxiedConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (p *proxiedConn) RemoteAddr() net.Addr {
+	return p.remoteAddr
+}
+
+func (d *proxiedDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := d.dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if ap, err := netip.ParseAddrPort(address); err == nil {
+		conn = &proxiedConn{Conn: conn, remoteAddr: net.TCPAddrFromAddrPort(ap)}
+	}
+	return conn, nil
+}
+
+// Dial is needed to satisfy the proxy.Dialer interface, which is required by proxy.PerHost.
+func (d *proxiedDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// httpConnectDialer dials connections through an HTTP proxy using the CONNECT method.
+type httpConnectDialer struct {
+	proxyURL *url.URL
+	forward  proxy.ContextDialer
+}
+
+// bufferedConn is a net.Conn that reads from a bufio.Reader. It is needed because the
+// bufio.Reader used when parsing the proxy's response might have consumed data that
+// the FTP server sent immediately after the connection was established.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func (d *httpConnectDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+func (d *httpConnectDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	proxyAddr := d.proxyURL.Host
+	if d.proxyURL.Port() == "" {
+		proxyAddr = net.JoinHostPort(d.proxyURL.Hostname(), "80")
+	}
+	conn, err := d.forward.DialContext(ctx, network, proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if dl, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(dl)
+	}
+	rq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if u := d.proxyURL.User; u != nil {
+		pw, _ := u.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(u.Username() + ":" + pw))
+		rq.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if err = rq.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	rs, err := http.ReadResponse(br, rq)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	_ = rs.Body.Close()
+	if rs.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy %s refused CONNECT to %s: %s", proxyAddr, address, rs.Status)
+	}
+	if err = conn.SetDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return &bufferedConn{Conn: conn, reader: br}, nil
+}
@@ -0,0 +1,229 @@
+package fs
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProxy is an in-process proxy that counts the number of connections that it has relayed.
+type testProxy struct {
+	net.Listener
+	relayed atomic.Int32
+}
+
+func (tp *testProxy) url(scheme string) string {
+	return fmt.Sprintf("%s://%s", scheme, tp.Addr())
+}
+
+func (tp *testProxy) relay(c net.Conn, br io.Reader, target string) {
+	defer c.Close()
+	tc, err := net.Dial("tcp", target)
+	if err != nil {
+		return
+	}
+	defer tc.Close()
+	tp.relayed.Add(1)
+	go func() {
+		_, _ = io.Copy(tc, br)
+		_ = tc.(*net.TCPConn).CloseWrite()
+	}()
+	_, _ = io.Copy(c, tc)
+}
+
+// startSOCKS5Proxy starts a minimal SOCKS5 proxy that only supports the CONNECT command without authentication.
+func startSOCKS5Proxy(t *testing.T) *testProxy {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tp := &testProxy{Listener: l}
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				br := bufio.NewReader(c)
+				target, err := socks5Handshake(br, c)
+				if err != nil {
+					_ = c.Close()
+					return
+				}
+				tp.relay(c, br, target)
+			}()
+		}
+	}()
+	return tp
+}
+
+func socks5Handshake(br *bufio.Reader, w io.Writer) (string, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(br, hdr); err != nil {
+		return "", err
+	}
+	if _, err := io.ReadFull(br, make([]byte, hdr[1])); err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte{5, 0}); err != nil {
+		return "", err
+	}
+	rq := make([]byte, 4)
+	if _, err := io.ReadFull(br, rq); err != nil {
+		return "", err
+	}
+	if rq[1] != 1 {
+		return "", errors.New("only CONNECT is supported")
+	}
+	var host string
+	switch rq[3] {
+	case 1:
+		ip := make([]byte, 4)
+		if _, err := io.ReadFull(br, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	case 3:
+		ln, err := br.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		name := make([]byte, ln)
+		if _, err := io.ReadFull(br, name); err != nil {
+			return "", err
+		}
+		host = string(name)
+	case 4:
+		ip := make([]byte, 16)
+		if _, err := io.ReadFull(br, ip); err != nil {
+			return "", err
+		}
+		host = net.IP(ip).String()
+	default:
+		return "", errors.New("unknown address type")
+	}
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(br, port); err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte{5, 0, 0, 1, 0, 0, 0, 0, 0, 0}); err != nil {
+		return "", err
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// startHTTPConnectProxy starts a minimal HTTP proxy that only supports the CONNECT method.
+func startHTTPConnectProxy(t *testing.T) *testProxy {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	tp := &testProxy{Listener: l}
+	srv := &http.Server{
+		ReadHeaderTimeout: time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodConnect {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			c, brw, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				return
+			}
+			if _, err = c.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n")); err != nil {
+				_ = c.Close()
+				return
+			}
+			tp.relay(c, brw.Reader, r.Host)
+		}),
+	}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return tp
+}
+
+func TestProxy(t *testing.T) {
+	ctx, cancel := context.WithCancel(testContext(t))
+	wg := sync.WaitGroup{}
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	root, port := startFTPServer(t, ctx, t.TempDir(), &wg)
+	require.NotEqual(t, uint16(0), port)
+	contents := []byte("Some text\n")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), contents, 0644))
+	addr := netip.MustParseAddrPort(fmt.Sprintf("127.0.0.1:%d", port))
+
+	// useProxy connects to the FTP server using the given dialer and performs a LIST and
+	// a RETR, so that both the control connection and data connections are used.
+	useProxy := func(t *testing.T, proxyURL string) {
+		dialer, err := newDialer(proxyURL)
+		require.NoError(t, err)
+		p := &connPool{dir: remoteDir, timeouts: Timeouts{}.withDefaults(5 * time.Second), dialer: dialer}
+		require.NoError(t, p.setAddr(addr))
+		defer p.quit()
+
+		conn, err := p.get(ctx)
+		require.NoError(t, err)
+		defer p.put(conn)
+		es, err := conn.List("")
+		require.NoError(t, err)
+		require.Len(t, es, 1)
+		assert.Equal(t, "test1.txt", es[0].Name)
+
+		rr, err := conn.Retr("test1.txt")
+		require.NoError(t, err)
+		data, err := io.ReadAll(rr)
+		require.NoError(t, err)
+		require.NoError(t, rr.Close())
+		assert.Equal(t, contents, data)
+	}
+
+	t.Run("SOCKS5", func(t *testing.T) {
+		tp := startSOCKS5Proxy(t)
+		useProxy(t, tp.url("socks5"))
+		// one control connection and two data connections
+		assert.Equal(t, int32(3), tp.relayed.Load())
+	})
+
+	t.Run("HTTP CONNECT", func(t *testing.T) {
+		tp := startHTTPConnectProxy(t)
+		useProxy(t, tp.url("http"))
+		assert.Equal(t, int32(3), tp.relayed.Load())
+	})
+
+	t.Run("Environment", func(t *testing.T) {
+		tp := startSOCKS5Proxy(t)
+		t.Setenv("ALL_PROXY", tp.url("socks5"))
+		useProxy(t, "")
+		assert.Equal(t, int32(3), tp.relayed.Load())
+	})
+
+	t.Run("Environment NO_PROXY", func(t *testing.T) {
+		tp := startSOCKS5Proxy(t)
+		t.Setenv("ALL_PROXY", tp.url("socks5"))
+		t.Setenv("NO_PROXY", "127.0.0.1")
+		useProxy(t, "")
+		assert.Equal(t, int32(0), tp.relayed.Load())
+	})
+
+	t.Run("Unsupported scheme", func(t *testing.T) {
+		_, err := newDialer("ftp://127.0.0.1:21")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,125 @@
+package fs
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimits declares bandwidth limits in bytes per second. A value of zero (or less)
+// means that the direction is unlimited.
+type RateLimits struct {
+	// Upload limits the total rate of data written to the FTP server from the mount.
+	Upload int64
+
+	// Download limits the total rate of data read from the FTP server by the mount.
+	Download int64
+
+	// HandleUpload limits the rate of data written using one single file handle.
+	HandleUpload int64
+
+	// HandleDownload limits the rate of data read using one single file handle.
+	HandleDownload int64
+}
+
+// WithRateLimits sets the initial bandwidth limits of the FTP client. The limits can be
+// changed at runtime using FTPClient.SetRateLimits.
+func WithRateLimits(rl RateLimits) Option {
+	return func(f *fuseImpl) {
+		f.rateLimits = rl
+	}
+}
+
+// newLimiter returns a token-bucket limiter that allows bps bytes per second. The bucket starts
+// full, so the first second worth of data is not delayed.
+func newLimiter(bps int64) *rate.Limiter {
+	if bps <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(bps), burst(bps))
+}
+
+// setLimit changes the rate of the given limiter. The burst is one second worth of data,
+// which is also the size of the largest chunk that can be waited for in one call.
+func setLimit(l *rate.Limiter, bps int64) {
+	if bps <= 0 {
+		l.SetLimit(rate.Inf)
+		return
+	}
+	l.SetBurst(burst(bps))
+	l.SetLimit(rate.Limit(bps))
+}
+
+// burst returns the burst of a limiter that allows bps bytes per second.
+func burst(bps int64) int {
+	if bps > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(bps)
+}
+
+// waitN blocks until n bytes are allowed by all the given limiters.
+func waitN(ctx context.Context, n int, limiters ...*rate.Limiter) error {
+	for _, l := range limiters {
+		if l.Limit() == rate.Inf {
+			continue
+		}
+		for n > 0 {
+			c := n
+			if b := l.Burst(); c > b {
+				c = b
+			}
+			if err := l.WaitN(ctx, c); err != nil {
+				return err
+			}
+			n -= c
+		}
+	}
+	return nil
+}
+
+// waitForCaller is waitN for data that is transferred on behalf of the process that made the
+// current FUSE call. It returns errInterrupted when that process is interrupted during the wait.
+func (f *fuseImpl) waitForCaller(ctx context.Context, n int, limiters ...*rate.Limiter) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := f.watchCaller(cancel)
+	err := waitN(ctx, n, limiters...)
+	if stop() {
+		return errInterrupted
+	}
+	return err
+}
+
+// limitedReader is an io.Reader that waits for the limiters after each read.
+type limitedReader struct {
+	io.Reader
+	ctx      context.Context
+	limiters []*rate.Limiter
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		if werr := waitN(r.ctx, n, r.limiters...); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	return n, err
+}
+
+// SetRateLimits changes the bandwidth limits. The new limits are also applied to already
+// open file handles.
+func (f *fuseImpl) SetRateLimits(rl RateLimits) {
+	f.Lock()
+	f.rateLimits = rl
+	setLimit(f.upload, rl.Upload)
+	setLimit(f.download, rl.Download)
+	for _, fe := range f.current {
+		setLimit(fe.upload, rl.HandleUpload)
+		setLimit(fe.download, rl.HandleDownload)
+	}
+	f.Unlock()
+}
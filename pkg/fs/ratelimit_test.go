@@ -0,0 +1,94 @@
+package fs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+	"golang.org/x/time/rate"
+)
+
+func TestLimitedReader(t *testing.T) {
+	const bps = 32 * 1024
+	data := make([]byte, 3*bps)
+	mount := newLimiter(0)
+	handle := newLimiter(bps)
+	lr := &limitedReader{
+		Reader:   bytes.NewReader(data),
+		ctx:      context.Background(),
+		limiters: []*rate.Limiter{mount, handle},
+	}
+
+	// The burst allows one second worth of data to pass immediately, so reading
+	// three seconds worth of data will take at least two seconds.
+	start := time.Now()
+	n, err := io.Copy(io.Discard, lr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(data)), n)
+	assert.Greater(t, time.Since(start), 1900*time.Millisecond)
+
+	// Removing the limit makes subsequent reads unrestricted.
+	setLimit(handle, 0)
+	lr.Reader = bytes.NewReader(data)
+	start = time.Now()
+	_, err = io.Copy(io.Discard, lr)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestRead(t *testing.T) {
+	const bps = 16
+	f, root := newTestClient(t, WithRateLimits(RateLimits{HandleDownload: bps}))
+	data := []byte("0123456789abcdefghijklmnopqrstuvwxyz0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), data, 0644))
+
+	errCode, fh := f.Open("/test1.txt", fuse.O_RDONLY)
+	require.Equal(t, 0, errCode)
+	defer f.Release("/test1.txt", fh)
+
+	// The burst allows one second worth of data to pass immediately
+	buf := make([]byte, bps)
+	start := time.Now()
+	require.Equal(t, bps, f.Read("/test1.txt", buf, 0, fh))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, data[:bps], buf)
+
+	if interruptsDetected {
+		// A reader that is killed while it waits for the limit gets an error promptly, and
+		// the data is read again by the next read of the same offset.
+		cmd := exec.Command("true")
+		require.NoError(t, cmd.Run())
+		pid := cmd.Process.Pid
+		orig := getcontext
+		getcontext = func() (uint32, uint32, int) { return 0, 0, pid }
+		t.Cleanup(func() { getcontext = orig })
+		f.mounted.Store(true)
+		t.Cleanup(func() { f.mounted.Store(false) })
+
+		buf = make([]byte, 2*bps)
+		start = time.Now()
+		assert.Equal(t, -fuse.EINTR, f.Read("/test1.txt", buf, bps, fh))
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+		pid = os.Getpid()
+		require.Equal(t, 2*bps, f.Read("/test1.txt", buf, bps, fh))
+		assert.Equal(t, data[bps:3*bps], buf)
+		assert.Equal(t, uint64(3*bps), f.current[fh].rof)
+		f.mounted.Store(false)
+	}
+
+	// The wait ends with an error when the client is shutting down
+	buf = make([]byte, 2*bps)
+	time.AfterFunc(100*time.Millisecond, f.cancel)
+	start = time.Now()
+	assert.Equal(t, -fuse.ECANCELED, f.Read("/test1.txt", buf, 3*bps, fh))
+	assert.Less(t, time.Since(start), time.Second)
+}
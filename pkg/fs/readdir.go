@@ -0,0 +1,96 @@
+package fs
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dirListing is the listing of a directory that is read using an open directory handle. The
+// entries are appended as they arrive from the FTP server, and are kept until the directory is
+// read from the start again, so that the offset of an entry remains valid for seekdir and telldir.
+type dirListing struct {
+	sync.Mutex
+
+	// cond is signalled when entries are added and when the listing ends.
+	cond *sync.Cond
+
+	// conn is the connection that performs the listing.
+	conn *ftpConn
+
+	entries []*entry
+
+	// ended is set when the listing has ended, and err is the error that ended it, if any.
+	ended bool
+	err   error
+
+	// done is closed when the goroutine that performs the listing has returned.
+	done chan struct{}
+}
+
+// listDir starts a listing of the directory of the given handle in the background, using the
+// connection of the handle. The operation timeout doesn't apply to the listing, because it spans
+// many calls to Readdir. The idle timeouts of the connection do. For the same reason, the span of
+// the listing isn't a child of the span of the Readdir in the given context, which ends first,
+// but links to it.
+func (f *fuseImpl) listDir(ctx context.Context, fe *info) *dirListing {
+	l := &dirListing{conn: fe.conn, done: make(chan struct{})}
+	l.cond = sync.NewCond(&l.Mutex)
+	link := trace.WithLinks(trace.LinkFromContext(ctx))
+	go func() {
+		defer close(l.done)
+		err := fe.pool.traceCmd(contextOrBackground(f.ctx), "LIST", fe.path, func() error {
+			return l.conn.list(relpath(fe.path), l.add)
+		}, link)
+		if err != nil {
+			f.logger().Debugf("listing of %s ended: %v", fe.path, err)
+		}
+		l.Lock()
+		l.ended = true
+		l.err = err
+		l.cond.Broadcast()
+		l.Unlock()
+	}()
+	return l
+}
+
+func (l *dirListing) add(e *entry) {
+	l.Lock()
+	l.entries = append(l.entries, e)
+	l.cond.Broadcast()
+	l.Unlock()
+}
+
+// get returns the entry at the given offset, waiting for it to arrive if necessary. A nil entry
+// is returned, together with the error that ended the listing, if any, when the listing ends
+// before the entry has arrived.
+func (l *dirListing) get(ofst int64) (*entry, error) {
+	l.Lock()
+	defer l.Unlock()
+	for int64(len(l.entries)) <= ofst && !l.ended {
+		l.cond.Wait()
+	}
+	if ofst < int64(len(l.entries)) {
+		return l.entries[ofst], nil
+	}
+	return nil, l.err
+}
+
+// hasEnded returns true if the listing has ended.
+func (l *dirListing) hasEnded() bool {
+	l.Lock()
+	defer l.Unlock()
+	return l.ended
+}
+
+// stop aborts the connection of the listing unless the listing has ended, and waits for it to end.
+func (l *dirListing) stop() {
+	select {
+	case <-l.done:
+		return
+	default:
+	}
+	l.conn.abort()
+	<-l.done
+}
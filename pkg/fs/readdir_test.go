@@ -0,0 +1,106 @@
+package fs
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestParseMLSDLine(t *testing.T) {
+	e, ok := parseMLSDLine("type=file;size=10;modify=20230102030405.123;UNIX.mode=0640; a file")
+	require.True(t, ok)
+	assert.Equal(t, "a file", e.Name)
+	assert.Equal(t, ftp.EntryTypeFile, e.Type)
+	assert.Equal(t, uint64(10), e.Size)
+	assert.Equal(t, time.Date(2023, 1, 2, 3, 4, 5, 123000000, time.UTC), e.Time)
+	assert.Equal(t, "0640", e.facts["unix.mode"])
+
+	e, ok = parseMLSDLine("type=dir;modify=20230102030405; sub")
+	require.True(t, ok)
+	assert.Equal(t, ftp.EntryTypeFolder, e.Type)
+
+	for _, line := range []string{
+		"type=cdir; /some/dir",
+		"type=pdir; /some",
+		"type=file;size=x; bad size",
+		"type=file;modify=yesterday; bad time",
+		"-rw-r--r-- 1 owner group 10 Jan 02 03:04 not mlsd",
+		"",
+	} {
+		_, ok = parseMLSDLine(line)
+		assert.False(t, ok, line)
+	}
+}
+
+func TestReaddir(t *testing.T) {
+	f, root := newTestClient(t)
+	const count = 2000
+	for i := 0; i < count; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(root, fmt.Sprintf("file%04d.txt", i)), nil, 0644))
+	}
+
+	type dirent struct {
+		name string
+		ofst int64
+	}
+	// readdir reads at most n entries from the given offset, and returns them
+	readdir := func(fh uint64, ofst int64, n int) []dirent {
+		var ds []dirent
+		require.Equal(t, 0, f.Readdir("/", func(name string, _ *fuse.Stat_t, ofst int64) bool {
+			if len(ds) == n {
+				return false
+			}
+			ds = append(ds, dirent{name, ofst})
+			return true
+		}, ofst, fh))
+		return ds
+	}
+
+	errCode, fh := f.Opendir("/")
+	require.Equal(t, 0, errCode)
+
+	// Read the directory in pages, continuing from the offset of the last entry of each page
+	var all []dirent
+	for ofst := int64(0); ; {
+		page := readdir(fh, ofst, 300)
+		if len(page) == 0 {
+			break
+		}
+		all = append(all, page...)
+		ofst = page[len(page)-1].ofst
+	}
+	require.Len(t, all, count)
+	names := make(map[string]struct{}, count)
+	for i, d := range all {
+		assert.Equal(t, int64(i+1), d.ofst)
+		names[d.name] = struct{}{}
+	}
+	assert.Len(t, names, count)
+
+	// Seeking back returns the same entries, even though the directory has changed
+	require.NoError(t, os.WriteFile(filepath.Join(root, "added.txt"), nil, 0644))
+	assert.Equal(t, all[500:510], readdir(fh, 500, 10))
+	assert.Empty(t, readdir(fh, count, 10))
+
+	// Rewinding starts a new listing
+	assert.Len(t, readdir(fh, 0, count+10), count+1)
+	require.Equal(t, 0, f.Releasedir("/", fh))
+
+	// Releasing a handle while its listing is in progress stops the listing
+	errCode, fh = f.Opendir("/")
+	require.Equal(t, 0, errCode)
+	assert.Len(t, readdir(fh, 0, 1), 1)
+	require.Equal(t, 0, f.Releasedir("/", fh))
+
+	// A temporary handle lists the whole directory
+	assert.Len(t, readdir(math.MaxUint64, 0, math.MaxInt), count+1)
+	assert.Equal(t, -fuse.ENOENT, f.Readdir("/", func(string, *fuse.Stat_t, int64) bool { return true }, 0, fh))
+}
@@ -0,0 +1,85 @@
+package fs
+
+import (
+	"errors"
+
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+// writeFlags are the open flags that require write access.
+const writeFlags = fuse.O_WRONLY | fuse.O_RDWR | fuse.O_APPEND | fuse.O_CREAT | fuse.O_TRUNC
+
+// WithReadOnly makes the file system read-only. A FuseHost then mounts it using "-o ro", and
+// all operations that would modify the FTP server fail with EROFS without contacting it.
+func WithReadOnly(ro bool) Option {
+	return func(f *fuseImpl) {
+		f.readOnly.Store(ro)
+	}
+}
+
+// readOnlyFS is implemented by file systems that the FuseHost should mount read-only.
+type readOnlyFS interface {
+	isReadOnly() bool
+}
+
+func (f *fuseImpl) isReadOnly() bool {
+	return f.readOnly.Load()
+}
+
+// SetReadOnly changes the read-only mode. A file system that was mounted read-only cannot be
+// made writable, because the kernel then rejects all modifications regardless.
+func (f *fuseImpl) SetReadOnly(ro bool) error {
+	if !ro && f.mountedReadOnly.Load() {
+		return errors.New("a file system that was mounted read-only must be remounted to become writable")
+	}
+	f.readOnly.Store(ro)
+	return nil
+}
+
+// denyWrite returns -EROFS when the file system is read-only, and zero otherwise.
+func (f *fuseImpl) denyWrite() int {
+	if f.readOnly.Load() {
+		return -fuse.EROFS
+	}
+	return 0
+}
+
+// Chmod is not supported. It fails with EROFS when the file system is read-only.
+func (f *fuseImpl) Chmod(path string, mode uint32) int {
+	if ec := f.denyWrite(); ec != 0 {
+		return ec
+	}
+	return f.FileSystemBase.Chmod(path, mode)
+}
+
+// Chown is not supported. It fails with EROFS when the file system is read-only.
+func (f *fuseImpl) Chown(path string, uid uint32, gid uint32) int {
+	if ec := f.denyWrite(); ec != 0 {
+		return ec
+	}
+	return f.FileSystemBase.Chown(path, uid, gid)
+}
+
+// Utimens is not supported. It fails with EROFS when the file system is read-only.
+func (f *fuseImpl) Utimens(path string, tmsp []fuse.Timespec) int {
+	if ec := f.denyWrite(); ec != 0 {
+		return ec
+	}
+	return f.FileSystemBase.Utimens(path, tmsp)
+}
+
+// Setxattr is not supported. It fails with EROFS when the file system is read-only.
+func (f *fuseImpl) Setxattr(path string, name string, value []byte, flags int) int {
+	if ec := f.denyWrite(); ec != 0 {
+		return ec
+	}
+	return f.FileSystemBase.Setxattr(path, name, value, flags)
+}
+
+// Removexattr is not supported. It fails with EROFS when the file system is read-only.
+func (f *fuseImpl) Removexattr(path string, name string) int {
+	if ec := f.denyWrite(); ec != 0 {
+		return ec
+	}
+	return f.FileSystemBase.Removexattr(path, name)
+}
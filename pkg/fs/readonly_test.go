@@ -0,0 +1,53 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestReadOnly(t *testing.T) {
+	f, root := newTestClient(t, WithReadOnly(true))
+	contents := []byte("Some text\n")
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), contents, 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(root, "sub"), 0755))
+
+	ec, _ := f.Create("/new.txt", fuse.O_WRONLY, 0644)
+	assert.Equal(t, -fuse.EROFS, ec)
+	ec, _ = f.Open("/test1.txt", fuse.O_RDWR)
+	assert.Equal(t, -fuse.EROFS, ec)
+	ec, _ = f.Open("/test1.txt", fuse.O_RDONLY|fuse.O_TRUNC)
+	assert.Equal(t, -fuse.EROFS, ec)
+	assert.Equal(t, -fuse.EROFS, f.Mkdir("/dir", 0755))
+	assert.Equal(t, -fuse.EROFS, f.Unlink("/test1.txt"))
+	assert.Equal(t, -fuse.EROFS, f.Rmdir("/sub"))
+	assert.Equal(t, -fuse.EROFS, f.Rename("/test1.txt", "/test2.txt"))
+	assert.Equal(t, -fuse.EROFS, f.Truncate("/test1.txt", 0, ^uint64(0)))
+	assert.Equal(t, -fuse.EROFS, f.Write("/test1.txt", []byte("x"), 0, 0))
+	assert.Equal(t, -fuse.EROFS, f.Chmod("/test1.txt", 0600))
+	assert.Equal(t, -fuse.EROFS, f.Chown("/test1.txt", 1, 1))
+	assert.Equal(t, -fuse.EROFS, f.Utimens("/test1.txt", nil))
+	assert.Equal(t, -fuse.EROFS, f.Setxattr("/test1.txt", "user.x", nil, 0))
+	assert.Equal(t, -fuse.EROFS, f.Removexattr("/test1.txt", "user.x"))
+
+	// Nothing was changed on the server, and reading still works.
+	es, err := os.ReadDir(root)
+	require.NoError(t, err)
+	require.Len(t, es, 2)
+	ec, fh := f.Open("/test1.txt", fuse.O_RDONLY)
+	require.Equal(t, 0, ec)
+	buf := make([]byte, 100)
+	n := f.Read("/test1.txt", buf, 0, fh)
+	assert.Equal(t, contents, buf[:n])
+	assert.Equal(t, 0, f.Release("/test1.txt", fh))
+
+	// A file system that was mounted read-only can't be made writable. Init records that the
+	// mount is read-only, but it can't be called here because the client then uses the FUSE context.
+	f.mountedReadOnly.Store(true)
+	require.Error(t, f.SetReadOnly(false))
+	require.NoError(t, f.SetReadOnly(true))
+}
@@ -0,0 +1,157 @@
+package fs
+
+import (
+	"context"
+	"time"
+)
+
+// Credentials are used when logging in to the FTP server. An empty User means anonymous.
+type Credentials struct {
+	User     string
+	Password string
+}
+
+// WithCredentials sets the credentials used by the FTP client. The default is to log in as
+// an anonymous user.
+func WithCredentials(c Credentials) Option {
+	return func(f *fuseImpl) {
+		f.pool.credentials = c
+	}
+}
+
+func (c Credentials) login() (string, string) {
+	if c.User == "" {
+		return "anonymous", "anonymous"
+	}
+	return c.User, c.Password
+}
+
+// defaultMaxIdle is the default maximum number of connections that the pool keeps open.
+const defaultMaxIdle = 64
+
+// PoolLimits controls the size of the connection pool.
+type PoolLimits struct {
+	// MaxIdle is the maximum number of open connections, busy and idle, above which idle
+	// connections are closed. Zero means the default, which is 64.
+	MaxIdle int
+}
+
+// WithPoolLimits sets the limits of the connection pool.
+func WithPoolLimits(l PoolLimits) Option {
+	return func(f *fuseImpl) {
+		f.pool.limits = l
+	}
+}
+
+func (l PoolLimits) maxIdle() int {
+	if l.MaxIdle <= 0 {
+		return defaultMaxIdle
+	}
+	return l.MaxIdle
+}
+
+// update changes the settings that are used when connections are created. A connection that
+// uses the new settings is created first, so that settings which don't work are rejected
+// and no change is made. Its failure doesn't affect the connections that use the current
+// settings. The pool is then drained: idle connections are closed immediately,
+// and busy connections are closed when they are returned, so that ongoing transfers are
+// allowed to complete.
+func (p *connPool) update(ctx context.Context, change func(*connSettings)) error {
+	u, err := p.prepareUpdate(ctx, change)
+	if err != nil {
+		return err
+	}
+	u.commit()
+	return nil
+}
+
+// settingsUpdate is a change of the settings of a pool that has been verified by connecting to
+// the FTP server using the new settings. No other update of the pool can be made until the
+// change is committed or cancelled.
+type settingsUpdate struct {
+	p    *connPool
+	s    connSettings
+	gen  uint64
+	conn *ftpConn
+}
+
+// prepareUpdate verifies the given change of the settings of the pool, and returns the update
+// that applies it.
+func (p *connPool) prepareUpdate(ctx context.Context, change func(*connSettings)) (*settingsUpdate, error) {
+	p.updateLock.Lock()
+	p.Lock()
+	s, gen := p.settings(), p.gen
+	p.Unlock()
+	change(&s)
+	conn, err := p.connectWith(ctx, s, gen+1, false)
+	if err != nil {
+		p.updateLock.Unlock()
+		return nil, err
+	}
+	return &settingsUpdate{p: p, s: s, gen: gen + 1, conn: conn}, nil
+}
+
+// commit applies the update and drains the pool.
+func (u *settingsUpdate) commit() {
+	p := u.p
+	defer p.updateLock.Unlock()
+	p.Lock()
+	p.dir, p.timeouts, p.credentials = u.s.dir, u.s.timeouts, u.s.credentials
+	p.gen = u.gen
+	idle := p.idleList.conns()
+	p.idleList = nil
+	p.Unlock()
+	p.closeList(idle, true)
+	p.put(u.conn)
+}
+
+// cancel discards the update and closes the connection that verified it.
+func (u *settingsUpdate) cancel() {
+	p := u.p
+	defer p.updateLock.Unlock()
+	p.Lock()
+	p.removeBusy(u.conn)
+	p.Unlock()
+	p.closeList([]*ftpConn{u.conn}, true)
+}
+
+// SetTimeouts changes the timeouts of the FTP client. The readTimeout replaces the zero
+// values in t, just like the readTimeout passed to NewFTPClient.
+func (f *fuseImpl) SetTimeouts(readTimeout time.Duration, t Timeouts) error {
+	return f.updatePools(func(s *connSettings) {
+		s.timeouts = t.withDefaults(readTimeout)
+	})
+}
+
+// SetDirectory changes the directory on the FTP server that is mounted. All open file
+// handles are evicted, because they refer to files in the previous directory.
+func (f *fuseImpl) SetDirectory(dir string) error {
+	if err := f.updatePools(func(s *connSettings) {
+		s.dir = dir
+	}); err != nil {
+		return err
+	}
+	f.clearPath("/")
+	f.inodes.reset()
+	f.attrs.reset()
+	return nil
+}
+
+// SetCredentials changes the credentials used when logging in to the FTP server. The
+// credentials of the users given to WithUserCredentials are not affected.
+func (f *fuseImpl) SetCredentials(c Credentials) error {
+	return f.pool.update(f.ctx, func(s *connSettings) {
+		s.credentials = c
+	})
+}
+
+// SetPoolLimits changes the limits of the connection pool. Surplus idle connections are
+// closed immediately.
+func (f *fuseImpl) SetPoolLimits(l PoolLimits) {
+	for _, p := range f.pools() {
+		p.Lock()
+		p.limits = l
+		p.Unlock()
+		p.tidy()
+	}
+}
@@ -0,0 +1,120 @@
+package fs
+
+import (
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestUpdateSettings(t *testing.T) {
+	var events []Event
+	var eventsLock sync.Mutex
+	f, root := newTestClient(t, WithEventHandler(func(e Event) {
+		eventsLock.Lock()
+		events = append(events, e)
+		eventsLock.Unlock()
+	}))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "a.txt"), []byte("a\n"), 0644))
+
+	stat := &fuse.Stat_t{}
+	assert.Equal(t, -fuse.ENOENT, f.Getattr("/a.txt", stat, 0))
+
+	busy, err := f.pool.get(f.ctx)
+	require.NoError(t, err)
+	require.NoError(t, f.SetDirectory(remoteDir+"/sub"))
+	assert.Equal(t, 0, f.Getattr("/a.txt", stat, 0))
+
+	// A connection that was busy during the update is discarded when it is returned.
+	f.pool.put(busy)
+	f.pool.Lock()
+	for _, c := range f.pool.idleList.conns() {
+		assert.NotSame(t, busy, c)
+		assert.Equal(t, f.pool.gen, c.gen)
+	}
+	f.pool.Unlock()
+
+	// Settings that don't work are rejected, and the previous settings remain.
+	require.Error(t, f.SetDirectory("nonexistent"))
+	assert.Equal(t, 0, f.Getattr("/a.txt", stat, 0))
+
+	// A failure to connect using new settings keeps the idle connections, and isn't reported
+	// as a lost connection.
+	require.Error(t, f.SetTimeouts(time.Second, Timeouts{Dial: time.Nanosecond}))
+	f.pool.Lock()
+	assert.NotZero(t, f.pool.idleList.size())
+	f.pool.Unlock()
+	eventsLock.Lock()
+	assert.Empty(t, events)
+	eventsLock.Unlock()
+
+	require.NoError(t, f.SetCredentials(Credentials{User: "anonymous", Password: "someone@example.com"}))
+	require.NoError(t, f.SetTimeouts(2*time.Second, Timeouts{}))
+	f.pool.Lock()
+	assert.Equal(t, 2*time.Second, f.pool.timeouts.Control)
+	f.pool.Unlock()
+
+	var conns []*ftpConn
+	for i := 0; i < 3; i++ {
+		c, err := f.pool.get(f.ctx)
+		require.NoError(t, err)
+		conns = append(conns, c)
+	}
+	for _, c := range conns {
+		f.pool.put(c)
+	}
+	f.SetPoolLimits(PoolLimits{MaxIdle: 1})
+	f.pool.Lock()
+	assert.Equal(t, 1, f.pool.idleList.size())
+	f.pool.Unlock()
+}
+
+func TestUpdatePools(t *testing.T) {
+	f, root := newTestClient(t)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "sub", "a.txt"), []byte("a\n"), 0644))
+	stat := &fuse.Stat_t{}
+	assert.Equal(t, -fuse.ENOENT, f.Getattr("/a.txt", stat, 0))
+
+	// A user pool that cannot reach the server
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := netip.MustParseAddrPort(l.Addr().String())
+	require.NoError(t, l.Close())
+	up := f.pool.withCredentials(Credentials{User: "anonymous", Password: "user2000@example.com"})
+	up.addr = closedAddr
+	f.userPoolsLock.Lock()
+	f.userPools = map[uint32]*connPool{2000: up}
+	f.userPoolsLock.Unlock()
+
+	// The change is rejected by the user pool, so the default pool isn't changed either
+	f.pool.Lock()
+	gen := f.pool.gen
+	f.pool.Unlock()
+	require.Error(t, f.SetDirectory(remoteDir+"/sub"))
+	f.pool.Lock()
+	assert.Equal(t, gen, f.pool.gen)
+	assert.Equal(t, remoteDir, f.pool.dir)
+	addr := f.pool.addr
+	f.pool.Unlock()
+	up.Lock()
+	assert.Equal(t, remoteDir, up.dir)
+	up.Unlock()
+	assert.Equal(t, -fuse.ENOENT, f.Getattr("/a.txt", stat, 0))
+
+	// All pools change when the change works for all of them
+	require.NoError(t, up.setAddr(addr))
+	require.NoError(t, f.SetDirectory(remoteDir+"/sub"))
+	up.Lock()
+	assert.Equal(t, remoteDir+"/sub", up.dir)
+	up.Unlock()
+	assert.Equal(t, 0, f.Getattr("/a.txt", stat, 0))
+}
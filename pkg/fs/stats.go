@@ -0,0 +1,185 @@
+package fs
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LatencyBuckets are the upper bounds of the buckets of the operation latency histograms.
+var LatencyBuckets = []time.Duration{
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// OpStats are the statistics of one FUSE operation.
+type OpStats struct {
+	// Count is the number of calls
+	Count uint64
+
+	// Errors is the number of calls that returned an error
+	Errors uint64
+
+	// TotalLatency is the sum of the latencies of all calls
+	TotalLatency time.Duration
+
+	// Buckets holds the number of calls with a latency less than or equal to the corresponding
+	// LatencyBuckets entry, and greater than the previous entry. The last element counts
+	// calls with a latency that exceeds the last LatencyBuckets entry.
+	Buckets []uint64
+}
+
+// PoolStats are the statistics of the connection pool.
+type PoolStats struct {
+	// Idle is the number of idle connections
+	Idle int
+
+	// Busy is the number of connections that are in use
+	Busy int
+
+	// Dials is the number of attempts to dial and log in to the FTP server
+	Dials uint64
+
+	// DialFailures is the number of dial attempts that failed
+	DialFailures uint64
+}
+
+// Stats are the statistics of an FTP client.
+type Stats struct {
+	// Operations maps the name of FUSE operations, e.g. "Getattr", to their statistics
+	Operations map[string]OpStats
+
+	// Errnos maps errno values to the number of operations that returned them
+	Errnos map[int]uint64
+
+	// ReplyCodes maps FTP reply codes to the number of FTP commands that failed with them
+	ReplyCodes map[int]uint64
+
+	// Pool is the total of the connection pools of all users
+	Pool PoolStats
+
+	// BytesRead is the number of bytes read from files on the FTP server
+	BytesRead uint64
+
+	// BytesWritten is the number of bytes written to files on the FTP server
+	BytesWritten uint64
+
+	// OpenHandles is the number of open file handles
+	OpenHandles int
+}
+
+// stats collects the statistics of a fuseImpl.
+type stats struct {
+	sync.Mutex
+	ops          map[string]*OpStats
+	errnos       map[int]uint64
+	replyCodes   map[int]uint64
+	bytesRead    atomic.Uint64
+	bytesWritten atomic.Uint64
+}
+
+// track records a call to the given operation that started at the given time. It is
+// intended to be deferred with a pointer to the result of the operation, where a negative
+// result is an error.
+func (s *stats) track(op string, start time.Time, result *int) {
+	lat := time.Since(start)
+	b := len(LatencyBuckets)
+	for i, ub := range LatencyBuckets {
+		if lat <= ub {
+			b = i
+			break
+		}
+	}
+	s.Lock()
+	if s.ops == nil {
+		s.ops = make(map[string]*OpStats)
+		s.errnos = make(map[int]uint64)
+	}
+	st, ok := s.ops[op]
+	if !ok {
+		st = &OpStats{Buckets: make([]uint64, len(LatencyBuckets)+1)}
+		s.ops[op] = st
+	}
+	st.Count++
+	st.TotalLatency += lat
+	st.Buckets[b]++
+	if rc := *result; rc < 0 {
+		st.Errors++
+		s.errnos[-rc]++
+	}
+	s.Unlock()
+}
+
+// replyCode records the reply code of an FTP command that failed. Nothing is recorded when s
+// is nil.
+func (s *stats) replyCode(code int) {
+	if s == nil {
+		return
+	}
+	s.Lock()
+	if s.replyCodes == nil {
+		s.replyCodes = make(map[int]uint64)
+	}
+	s.replyCodes[code]++
+	s.Unlock()
+}
+
+func (s *stats) snapshot() Stats {
+	st := Stats{
+		Operations:   make(map[string]OpStats),
+		Errnos:       make(map[int]uint64),
+		ReplyCodes:   make(map[int]uint64),
+		BytesRead:    s.bytesRead.Load(),
+		BytesWritten: s.bytesWritten.Load(),
+	}
+	s.Lock()
+	for op, ost := range s.ops {
+		c := *ost
+		c.Buckets = append([]uint64(nil), ost.Buckets...)
+		st.Operations[op] = c
+	}
+	for k, v := range s.errnos {
+		st.Errnos[k] = v
+	}
+	for k, v := range s.replyCodes {
+		st.ReplyCodes[k] = v
+	}
+	s.Unlock()
+	return st
+}
+
+// stats returns the statistics of the pool
+func (p *connPool) stats() PoolStats {
+	p.Lock()
+	ps := PoolStats{
+		Idle: p.idleList.size(),
+		Busy: p.busyList.size(),
+	}
+	p.Unlock()
+	ps.Dials = p.dials.Load()
+	ps.DialFailures = p.dialFailures.Load()
+	return ps
+}
+
+// Stats returns a snapshot of the statistics of the client.
+func (f *fuseImpl) Stats() Stats {
+	st := f.stats.snapshot()
+	for _, p := range f.pools() {
+		ps := p.stats()
+		st.Pool.Idle += ps.Idle
+		st.Pool.Busy += ps.Busy
+		st.Pool.Dials += ps.Dials
+		st.Pool.DialFailures += ps.DialFailures
+	}
+	st.OpenHandles = f.cacheSize()
+	return st
+}
@@ -0,0 +1,48 @@
+package fs
+
+import (
+	"context"
+	"net/textproto"
+	"testing"
+	"time"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+func TestStats(t *testing.T) {
+	f := &fuseImpl{current: make(map[uint64]*info)}
+	call := func(op string, start time.Time, result int) {
+		f.stats.track(op, start, &result)
+	}
+	call("Getattr", time.Now(), 0)
+	call("Getattr", time.Now().Add(-2*time.Second), -fuse.ENOENT)
+	call("Read", time.Now().Add(-time.Minute), 4096)
+	// Reply codes are counted once for each failed command, not each time they are mapped
+	f.pool.replies = &f.stats
+	err := f.pool.traceCmd(context.Background(), "MLST", "/x", func() error {
+		return &textproto.Error{Code: ftp.StatusFileUnavailable, Msg: "no such file"}
+	})
+	assert.Equal(t, -fuse.ENOENT, f.errToFuseErr(err))
+	assert.Equal(t, -fuse.ENOENT, f.errToFuseErr(err))
+
+	st := f.Stats()
+	ga, ok := st.Operations["Getattr"]
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), ga.Count)
+	assert.Equal(t, uint64(1), ga.Errors)
+	assert.GreaterOrEqual(t, ga.TotalLatency, 2*time.Second)
+	require.Len(t, ga.Buckets, len(LatencyBuckets)+1)
+	assert.Equal(t, uint64(1), ga.Buckets[0])
+	assert.Equal(t, uint64(1), ga.Buckets[len(LatencyBuckets)-2]) // <= 5s
+
+	rd := st.Operations["Read"]
+	assert.Equal(t, uint64(0), rd.Errors)
+	assert.Equal(t, uint64(1), rd.Buckets[len(LatencyBuckets)]) // > 10s
+
+	assert.Equal(t, map[int]uint64{fuse.ENOENT: 1}, st.Errnos)
+	assert.Equal(t, map[int]uint64{ftp.StatusFileUnavailable: 1}, st.ReplyCodes)
+	assert.Equal(t, "ENOENT", ErrnoName(fuse.ENOENT))
+}
@@ -0,0 +1,48 @@
+package fs
+
+import (
+	"time"
+)
+
+// Timeouts controls how long the FTP client waits for the FTP server. A zero Dial, Control,
+// or DataIdle timeout is replaced by the readTimeout that is passed to NewFTPClient.
+type Timeouts struct {
+	// Dial is the maximum time that it may take to establish a control or data connection,
+	// including any proxy negotiation.
+	Dial time.Duration
+
+	// Control is the maximum time to wait for the FTP server when sending a command or
+	// reading a reply on a control connection.
+	Control time.Duration
+
+	// DataIdle is the maximum time that a data connection may remain idle during a transfer.
+	// A large transfer will never time out as long as data keeps flowing.
+	DataIdle time.Duration
+
+	// Operation is the deadline for a complete FUSE operation, including all FTP commands
+	// and data transfers that it causes. Zero means no deadline. Uploads are performed in
+	// the background between calls to Write and are therefore bounded by DataIdle only.
+	Operation time.Duration
+}
+
+// WithTimeouts sets the timeouts used by the FTP client.
+func WithTimeouts(t Timeouts) Option {
+	return func(f *fuseImpl) {
+		f.pool.timeouts = t
+	}
+}
+
+// withDefaults returns a copy of the timeouts where the zero values of Dial, Control, and
+// DataIdle have been replaced by the given default.
+func (t Timeouts) withDefaults(dflt time.Duration) Timeouts {
+	if t.Dial == 0 {
+		t.Dial = dflt
+	}
+	if t.Control == 0 {
+		t.Control = dflt
+	}
+	if t.DataIdle == 0 {
+		t.DataIdle = dflt
+	}
+	return t
+}
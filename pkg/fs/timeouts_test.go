@@ -0,0 +1,88 @@
+package fs
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+)
+
+// startHangingServer starts a fake FTP server that accepts a login and then never
+// replies to any other command.
+func startHangingServer(t *testing.T) netip.AddrPort {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = c.Write([]byte("220 ready\r\n"))
+				sc := bufio.NewScanner(c)
+				for sc.Scan() {
+					var reply string
+					switch cmd, _, _ := strings.Cut(sc.Text(), " "); cmd {
+					case "USER":
+						reply = "331 password please\r\n"
+					case "PASS":
+						reply = "230 logged in\r\n"
+					case "FEAT":
+						reply = "211-Features:\r\n MLST type*;size*;modify*;\r\n211 End\r\n"
+					case "TYPE":
+						reply = "200 ok\r\n"
+					default:
+						continue
+					}
+					if _, err := c.Write([]byte(reply)); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return netip.MustParseAddrPort(l.Addr().String())
+}
+
+func TestTimeouts(t *testing.T) {
+	addr := startHangingServer(t)
+	f := &fuseImpl{}
+	newPool := func(t *testing.T, to Timeouts) *connPool {
+		p := &connPool{timeouts: to, dialer: &net.Dialer{}}
+		require.NoError(t, p.setAddr(addr))
+		t.Cleanup(p.quit)
+		return p
+	}
+
+	t.Run("Control", func(t *testing.T) {
+		p := newPool(t, Timeouts{Control: 200 * time.Millisecond})
+		conn, err := p.get(context.Background())
+		require.NoError(t, err)
+		start := time.Now()
+		_, err = conn.GetEntry("somefile.txt")
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.Equal(t, -fuse.ETIMEDOUT, f.errToFuseErr(err))
+	})
+
+	t.Run("Operation", func(t *testing.T) {
+		p := newPool(t, Timeouts{Control: time.Minute, Operation: 200 * time.Millisecond})
+		conn, err := p.get(context.Background())
+		require.NoError(t, err)
+		start := time.Now()
+		endOp := p.startOp(conn)
+		_, err = conn.GetEntry("somefile.txt")
+		endOp()
+		assert.Less(t, time.Since(start), 2*time.Second)
+		assert.Equal(t, -fuse.ETIMEDOUT, f.errToFuseErr(err))
+	})
+}
@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"context"
+	"errors"
+	"net/textproto"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/datawire/go-fuseftp/pkg/fs"
+
+// WithTracerProvider sets the provider of the tracer that creates a span for each FUSE
+// operation, with child spans for connection pool acquisition, dial and login, and each FTP
+// command. The global provider is used by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(f *fuseImpl) {
+		f.pool.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// noopTracer is used by clients that have no tracer.
+var noopTracer = trace.NewNoopTracerProvider().Tracer(tracerName)
+
+func (p *connPool) getTracer() trace.Tracer {
+	if p.tracer == nil {
+		return noopTracer
+	}
+	return p.tracer
+}
+
+// startOp starts the span of a FUSE operation. The returned function must be deferred with
+// a pointer to the result of the operation. It ends the span and records the statistics
+// of the operation.
+func (f *fuseImpl) startOp(op string, path string) (context.Context, func(*int)) {
+	start := time.Now()
+	ctx, span := f.pool.getTracer().Start(contextOrBackground(f.ctx), op, trace.WithAttributes(attribute.String("fuse.path", path)))
+	return ctx, func(result *int) {
+		f.stats.track(op, start, result)
+		if rc := *result; rc < 0 {
+			name := ErrnoName(-rc)
+			span.SetAttributes(attribute.String("fuse.errno", name))
+			span.SetStatus(codes.Error, name)
+		}
+		span.End()
+	}
+}
+
+// traceCmd runs fn in a span that represents the given FTP command. The span is started using
+// the given options in addition to the attributes of the command.
+func (p *connPool) traceCmd(ctx context.Context, cmd, path string, fn func() error, opts ...trace.SpanStartOption) error {
+	opts = append(opts, trace.WithAttributes(
+		attribute.String("ftp.command", cmd),
+		attribute.String("ftp.path", path)))
+	_, span := p.getTracer().Start(ctx, "ftp."+cmd, opts...)
+	err := fn()
+	var tpe *textproto.Error
+	if errors.As(err, &tpe) && tpe.Code >= 400 {
+		p.replies.replyCode(tpe.Code)
+	}
+	endSpan(span, err)
+	return err
+}
+
+// endSpan records the error, if any, and ends the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// contextOrBackground returns ctx, or context.Background if ctx is nil.
+func contextOrBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
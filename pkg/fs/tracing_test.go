@@ -0,0 +1,55 @@
+package fs
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/winfsp/cgofuse/fuse"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	f, root := newTestClient(t, WithTracerProvider(tp))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "test1.txt"), []byte("Some text\n"), 0644))
+
+	st := &fuse.Stat_t{}
+	require.Equal(t, 0, f.Getattr("/test1.txt", st, math.MaxUint64))
+	require.Equal(t, -fuse.ENOENT, f.Getattr("/nosuchfile.txt", st, math.MaxUint64))
+
+	spans := exp.GetSpans()
+	byName := make(map[string][]tracetest.SpanStub)
+	for _, s := range spans {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+	require.Len(t, byName["Getattr"], 2)
+	require.Len(t, byName["ftp.MLST"], 2)
+	require.Len(t, byName["pool.acquire"], 2)
+	for i, op := range byName["Getattr"] {
+		assert.Equal(t, op.SpanContext.SpanID(), byName["ftp.MLST"][i].Parent.SpanID())
+		assert.Equal(t, op.SpanContext.SpanID(), byName["pool.acquire"][i].Parent.SpanID())
+	}
+	assert.Contains(t, byName["Getattr"][1].Attributes, attribute.String("fuse.errno", "ENOENT"))
+
+	// A listing outlives the Readdir that starts it, so its span links to the span of the
+	// Readdir instead of being its child.
+	exp.Reset()
+	require.Equal(t, 0, f.Readdir("/", func(string, *fuse.Stat_t, int64) bool { return true }, 0, math.MaxUint64))
+	byName = make(map[string][]tracetest.SpanStub)
+	for _, s := range exp.GetSpans() {
+		byName[s.Name] = append(byName[s.Name], s)
+	}
+	require.Len(t, byName["Readdir"], 1)
+	require.Len(t, byName["ftp.LIST"], 1)
+	list := byName["ftp.LIST"][0]
+	assert.False(t, list.Parent.IsValid())
+	require.Len(t, list.Links, 1)
+	assert.Equal(t, byName["Readdir"][0].SpanContext.SpanID(), list.Links[0].SpanContext.SpanID())
+}
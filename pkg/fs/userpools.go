@@ -0,0 +1,164 @@
+package fs
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// WithUserCredentials makes the client log in to the FTP server using different credentials
+// for FUSE calls made by the given local users. Each of those users gets a separate connection
+// pool, which is created on first use. Calls made by other users, and calls for which the user
+// is unknown, use the credentials given by WithCredentials.
+func WithUserCredentials(uc map[uint32]Credentials) Option {
+	return func(f *fuseImpl) {
+		f.userCredentials = uc
+	}
+}
+
+// callerPool returns the connection pool used for calls made by the caller.
+func (f *fuseImpl) callerPool() (*connPool, error) {
+	if len(f.userCredentials) == 0 {
+		return &f.pool, nil
+	}
+	uid, _, ok := f.caller()
+	if !ok {
+		return &f.pool, nil
+	}
+	c, ok := f.userCredentials[uid]
+	if !ok {
+		return &f.pool, nil
+	}
+
+	for {
+		f.userPoolsLock.Lock()
+		if p, ok := f.userPools[uid]; ok {
+			f.userPoolsLock.Unlock()
+			return p, nil
+		}
+		dialed, ok := f.userPoolDials[uid]
+		if !ok {
+			break
+		}
+		// Another call is creating the pool. Wait for it, and try again if it fails.
+		f.userPoolsLock.Unlock()
+		<-dialed
+	}
+	dialed := make(chan struct{})
+	defer close(dialed)
+	if f.userPoolDials == nil {
+		f.userPoolDials = make(map[uint32]chan struct{})
+	}
+	f.userPoolDials[uid] = dialed
+	f.userPoolsLock.Unlock()
+
+	// Connect up front, outside the lock, so that credentials that don't work are reported
+	// right away and the pool is tried again by the next call.
+	p := f.pool.withCredentials(c)
+	conn, err := p.connect(contextOrBackground(f.ctx))
+	if err == nil {
+		p.put(conn)
+	}
+
+	f.userPoolsLock.Lock()
+	delete(f.userPoolDials, uid)
+	if err == nil {
+		if f.userPools == nil {
+			f.userPools = make(map[uint32]*connPool)
+		}
+		f.userPools[uid] = p
+	}
+	f.userPoolsLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if f.destroyed.Load() {
+		// Destroy may have missed the new pool
+		p.quit()
+	}
+	f.logger().Debugf("created a connection pool for uid %d", uid)
+	return p, nil
+}
+
+// withCredentials returns a new pool that has the same settings as this pool, except for the
+// credentials.
+func (p *connPool) withCredentials(c Credentials) *connPool {
+	p.Lock()
+	defer p.Unlock()
+	return &connPool{
+		addr:        p.addr,
+		dir:         p.dir,
+		timeouts:    p.timeouts,
+		credentials: c,
+		limits:      p.limits,
+		proxyURL:    p.proxyURL,
+		dialer:      p.dialer,
+		onEvent:     p.onEvent,
+		tracer:      p.tracer,
+		replies:     p.replies,
+		log:         p.log,
+	}
+}
+
+// pools returns the default connection pool followed by the pools of the users, ordered by uid.
+func (f *fuseImpl) pools() []*connPool {
+	f.userPoolsLock.Lock()
+	defer f.userPoolsLock.Unlock()
+	uids := make([]uint32, 0, len(f.userPools))
+	for uid := range f.userPools {
+		uids = append(uids, uid)
+	}
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+	ps := make([]*connPool, 0, 1+len(f.userPools))
+	ps = append(ps, &f.pool)
+	for _, uid := range uids {
+		ps = append(ps, f.userPools[uid])
+	}
+	return ps
+}
+
+// updatePools applies the given change to the settings of all pools. The change is verified
+// using every pool before it is applied to any of them, so when it fails for one pool, no pool
+// is changed. The pools are always updated in the same order, because each pool is locked from
+// the time that the change is verified until it is applied.
+func (f *fuseImpl) updatePools(change func(*connSettings)) error {
+	var us []*settingsUpdate
+	for _, p := range f.pools() {
+		u, err := p.prepareUpdate(f.ctx, change)
+		if err != nil {
+			for _, u := range us {
+				u.cancel()
+			}
+			return err
+		}
+		us = append(us, u)
+	}
+	for _, u := range us {
+		u.commit()
+	}
+	return nil
+}
+
+// maintainPools periodically closes surplus idle connections, drops expired attributes, and
+// probes the FTP server using all pools until the given context is cancelled.
+func (f *fuseImpl) maintainPools(ctx context.Context) {
+	ticker := time.NewTicker(stalePeriod)
+	probeTicker := time.NewTicker(probeInterval)
+	defer ticker.Stop()
+	defer probeTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, p := range f.pools() {
+				p.tidy()
+			}
+			f.attrs.prune()
+		case <-probeTicker.C:
+			for _, p := range f.pools() {
+				p.probe(ctx)
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// readOnlyMethods are the methods that only report the state of the daemon. On the unix socket,
+// all other methods, including those that are added later, can only be called by the uids that
+// are allowed to do so.
+var readOnlyMethods = map[string]bool{
+	rpc.FuseFTP_Version_FullMethodName:     true,
+	rpc.FuseFTP_ListMounts_FullMethodName:  true,
+	rpc.FuseFTP_GetMount_FullMethodName:    true,
+	rpc.FuseFTP_WatchEvents_FullMethodName: true,
+	rpc.FuseFTP_GetStats_FullMethodName:    true,
+}
+
+// healthMethodPrefix is the prefix of the methods of the grpc.health.v1 service. Health checks
+// on the TCP listener do not require a token.
+const healthMethodPrefix = "/grpc.health.v1.Health/"
+
+// authorizer returns an error when the caller, as described by the context, is not allowed to
+// call the given method.
+type authorizer func(ctx context.Context, fullMethod string) error
+
+// interceptors returns the server options that install the given authorizer for unary and
+// streaming calls.
+func interceptors(auth authorizer) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if err := auth(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if err := auth(ss.Context(), info.FullMethod); err != nil {
+				return err
+			}
+			return handler(srv, ss)
+		}),
+	}
+}
+
+// tokenAuth returns an authorizer that requires an "authorization: Bearer <token>" header.
+func tokenAuth(token string) authorizer {
+	want := []byte("Bearer " + token)
+	return func(ctx context.Context, fullMethod string) error {
+		if strings.HasPrefix(fullMethod, healthMethodPrefix) {
+			return nil
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get("authorization") {
+			if subtle.ConstantTimeCompare([]byte(v), want) == 1 {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "missing or invalid bearer token")
+	}
+}
+
+// uidAuth returns an authorizer that allows all uids to call the read-only methods and health
+// checks, and only allows the given uids to call the other methods. The uid of the caller is
+// obtained from the peerCredAddr of a connection that was accepted by a peerCredListener.
+func uidAuth(allowed map[uint32]bool) authorizer {
+	return func(ctx context.Context, fullMethod string) error {
+		if readOnlyMethods[fullMethod] || strings.HasPrefix(fullMethod, healthMethodPrefix) {
+			return nil
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if pa, ok := p.Addr.(*peerCredAddr); ok && pa.known && allowed[pa.uid] {
+				return nil
+			}
+		}
+		return status.Error(codes.PermissionDenied, "the calling user is not allowed to change mounts")
+	}
+}
+
+// parseUIDs parses a comma separated list of numeric user ids. The uid of the daemon is always
+// included, so that the user that runs the daemon can change its mounts.
+func parseUIDs(s string) (map[uint32]bool, error) {
+	uids := map[uint32]bool{uint32(os.Getuid()): true}
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		uid, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uid %q", f)
+		}
+		uids[uint32(uid)] = true
+	}
+	return uids, nil
+}
+
+// readToken reads a bearer token from the given file. Leading and trailing white space is
+// ignored.
+func readToken(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", file)
+	}
+	return token, nil
+}
+
+// serverTLS returns the credentials used by the TCP listener. Client certificates are required
+// and verified when a clientCAFile is given.
+func serverTLS(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("a TCP listener requires a TLS certificate and key")
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("found no certificates in %s", clientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// peerCredAddr is the remote address of a connection accepted by a peerCredListener. It
+// carries the uid of the peer process.
+type peerCredAddr struct {
+	uid   uint32
+	known bool
+}
+
+func (a *peerCredAddr) Network() string {
+	return "unix"
+}
+
+func (a *peerCredAddr) String() string {
+	if !a.known {
+		return "uid=unknown"
+	}
+	return "uid=" + strconv.FormatUint(uint64(a.uid), 10)
+}
+
+type peerCredConn struct {
+	*net.UnixConn
+	addr *peerCredAddr
+}
+
+func (c *peerCredConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// peerCredListener is a unix socket listener that obtains the credentials of the peer of each
+// accepted connection.
+type peerCredListener struct {
+	*net.UnixListener
+}
+
+func (l peerCredListener) Accept() (net.Conn, error) {
+	c, err := l.AcceptUnix()
+	if err != nil {
+		return nil, err
+	}
+	addr := &peerCredAddr{}
+	if addr.uid, err = peerUID(c); err != nil {
+		logrus.Warnf("unable to obtain peer credentials: %v", err)
+	} else {
+		addr.known = true
+	}
+	return &peerCredConn{UnixConn: c, addr: addr}, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func TestUIDAuth(t *testing.T) {
+	auth := uidAuth(map[uint32]bool{1000: true})
+	caller := func(addr *peerCredAddr) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	}
+	allowed := caller(&peerCredAddr{uid: 1000, known: true})
+	other := caller(&peerCredAddr{uid: 1001, known: true})
+	unknown := caller(&peerCredAddr{uid: 1000})
+
+	for _, m := range []string{
+		rpc.FuseFTP_Version_FullMethodName,
+		rpc.FuseFTP_ListMounts_FullMethodName,
+		rpc.FuseFTP_WatchEvents_FullMethodName,
+		"/grpc.health.v1.Health/Check",
+	} {
+		assert.NoError(t, auth(other, m), m)
+		assert.NoError(t, auth(unknown, m), m)
+	}
+	for _, m := range []string{
+		rpc.FuseFTP_Mount_FullMethodName,
+		rpc.FuseFTP_UpdateMount_FullMethodName,
+		"/datawire.fuseftp.FuseFTP/NotYetKnown",
+	} {
+		assert.NoError(t, auth(allowed, m), m)
+		assert.Equal(t, codes.PermissionDenied, status.Code(auth(other, m)), m)
+		assert.Equal(t, codes.PermissionDenied, status.Code(auth(unknown, m)), m)
+		assert.Equal(t, codes.PermissionDenied, status.Code(auth(context.Background(), m)), m)
+	}
+}
+
+func TestParseUIDs(t *testing.T) {
+	uids, err := parseUIDs("1000, 1001,")
+	require.NoError(t, err)
+	assert.Equal(t, map[uint32]bool{1000: true, 1001: true, uint32(os.Getuid()): true}, uids)
+
+	_, err = parseUIDs("1000,root")
+	assert.Error(t, err)
+}
+
+func TestTokenAuth(t *testing.T) {
+	auth := tokenAuth("secret")
+	withAuth := func(v string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", v))
+	}
+
+	assert.NoError(t, auth(withAuth("Bearer secret"), rpc.FuseFTP_Mount_FullMethodName))
+	assert.NoError(t, auth(context.Background(), "/grpc.health.v1.Health/Check"))
+	for name, ctx := range map[string]context.Context{
+		"none":   context.Background(),
+		"wrong":  withAuth("Bearer other"),
+		"scheme": withAuth("secret"),
+	} {
+		assert.Equal(t, codes.Unauthenticated, status.Code(auth(ctx, rpc.FuseFTP_Version_FullMethodName)), name)
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+const progName = "fuseftp"
+
+// defaultReadTimeout is used by mounts that are declared on the command line or in the config
+// file and that have no read timeout.
+const defaultReadTimeout = 30 * time.Second
+
+// defaultShutdownTimeout is the maximum time that the daemon waits for its mounts to be
+// unmounted when it is terminated.
+const defaultShutdownTimeout = 30 * time.Second
+
+type command struct {
+	name  string
+	short string
+	run   func(args []string) int
+}
+
+var commands = []command{
+	{"serve", "run the daemon", runServe},
+	{"mount", "mount an FTP server", runMount},
+	{"unmount", "unmount a mount", runUnmount},
+	{"list", "list the mounts of the daemon", runList},
+	{"log-level", "change the log level of the daemon or of a mount", runLogLevel},
+	{"version", "print the version", runVersion},
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "Usage: %s <command> [flags] [arguments]\n\nCommands:\n", progName)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", c.name, c.short)
+	}
+	fmt.Fprintf(w, "\nUse \"%s <command> -h\" for more information about a command.\n", progName)
+}
+
+// run executes the command given by the arguments and returns the exit code. For backward
+// compatibility, arguments that do not start with a command are passed to the serve command.
+func run(args []string) int {
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	switch args[0] {
+	case "help", "-h", "-help", "--help":
+		usage()
+		return 0
+	}
+	c, cArgs := findCommand(args)
+	return c.run(cArgs)
+}
+
+// findCommand returns the command given by the first of the arguments, together with the
+// arguments of that command. Arguments that do not start with a command are the arguments of
+// the serve command.
+func findCommand(args []string) (command, []string) {
+	for _, c := range commands {
+		if c.name == args[0] {
+			return c, args[1:]
+		}
+	}
+	return commands[0], args
+}
+
+// defaultSocket returns the value of the FUSEFTP_SOCKET environment variable, or a socket in
+// the temporary directory when it isn't set.
+func defaultSocket() string {
+	if s := os.Getenv("FUSEFTP_SOCKET"); s != "" {
+		return s
+	}
+	return filepath.Join(os.TempDir(), "fuseftp.sock")
+}
+
+// dial returns a client of the daemon that listens on the given unix socket.
+func dial(socket string) (rpc.FuseFTPClient, func(), error) {
+	conn, err := grpc.Dial("unix:"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+	return rpc.NewFuseFTPClient(conn), func() { _ = conn.Close() }, nil
+}
+
+// signalContext returns a context that is cancelled on SIGINT or SIGTERM.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func runMount(args []string) int {
+	flags := flag.NewFlagSet("mount", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket of the daemon")
+	foreground := flags.Bool("foreground", false, "mount in this process, without a daemon, until interrupted")
+	var mc mountConfig
+	flags.StringVar(&mc.Directory, "dir", "", "the directory on the FTP server to mount")
+	flags.StringVar(&mc.User, "user", "", "log in to the FTP server as this user, using the password in $FUSEFTP_PASSWORD. Anonymous when empty")
+	flags.DurationVar(&mc.ReadTimeout, "read-timeout", defaultReadTimeout, "read timeout, used as the default for the dial, control, and data idle timeouts")
+	flags.DurationVar(&mc.OperationTimeout, "operation-timeout", 0, "deadline for a complete file system operation")
+	flags.DurationVar(&mc.AttrCacheTTL, "attr-cache-ttl", 0, "time that the attributes returned by a directory listing are used to answer lookups. Negative disables the cache")
+	flags.StringVar(&mc.ProxyURL, "proxy", "", "URL of a SOCKS5 or HTTP CONNECT proxy")
+	flags.BoolVar(&mc.AutoRemount, "auto-remount", false, "remount when the mount dies")
+	flags.BoolVar(&mc.ReadOnly, "read-only", false, "mount read-only")
+	flags.BoolVar(&mc.AccessChecks, "access-checks", false, "check the permissions of each call in the daemon instead of the kernel")
+	flags.BoolVar(&mc.Fuse.AllowOther, "allow-other", false, "give all users access to the mount")
+	allowRoot := flags.Bool("allow-root", true, "give root access to the mount. Ignored when -allow-other is given")
+	flags.StringVar(&mc.Fuse.FSName, "fsname", "", "the name of the file system, shown as the source of the mount")
+	flags.Func("o", "additional FUSE mount option, in the name or name=value form. May be repeated", func(s string) error {
+		mc.Fuse.Extra = append(mc.Fuse.Extra, s)
+		return nil
+	})
+	flags.StringVar(&mc.LogLevel, "log-level", "", "the logrus log level")
+	flags.Int64Var(&mc.RateLimits.Upload, "upload-limit", 0, "upload limit in bytes per second")
+	flags.Int64Var(&mc.RateLimits.Download, "download-limit", 0, "download limit in bytes per second")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s mount [flags] <FTP server host:port> <mount point>\n\nMount an FTP server using the daemon, or in this process when -foreground is given.\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "allow-root" && !mc.Fuse.AllowOther {
+			mc.Fuse.AllowRoot = allowRoot
+		}
+	})
+	mc.Password = os.Getenv("FUSEFTP_PASSWORD")
+	mc.FtpServer = flags.Arg(0)
+	mc.MountPoint = flags.Arg(1)
+	if !filepath.IsAbs(mc.MountPoint) && !isDriveLetter(mc.MountPoint) {
+		if abs, err := filepath.Abs(mc.MountPoint); err == nil {
+			mc.MountPoint = abs
+		}
+	}
+	rq, err := mc.request()
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+
+	ctx, cancel := signalContext()
+	defer cancel()
+	if *foreground {
+		s := newService(context.Background())
+		id, err := s.Mount(ctx, rq)
+		if err != nil {
+			log.Println(err)
+			return 1
+		}
+		fmt.Printf("mounted %s with id %d, press Ctrl-C to unmount\n", rq.MountPoint, id.Id)
+		<-ctx.Done()
+		cancel()
+		sdCtx, sdCancel := context.WithTimeout(context.Background(), defaultShutdownTimeout)
+		defer sdCancel()
+		if err := s.shutdown(sdCtx); err != nil {
+			log.Printf("%s was not unmounted within %s\n", rq.MountPoint, defaultShutdownTimeout)
+			return 1
+		}
+		return 0
+	}
+
+	client, closeConn, err := dial(*socket)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer closeConn()
+	id, err := client.Mount(ctx, rq)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	fmt.Println(id.Id)
+	return 0
+}
+
+// isDriveLetter returns true for mount points like "T:" that are used on Windows.
+func isDriveLetter(s string) bool {
+	return len(s) == 2 && s[1] == ':'
+}
+
+func runUnmount(args []string) int {
+	flags := flag.NewFlagSet("unmount", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket of the daemon")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s unmount [flags] <mount id or mount point>\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	client, closeConn, err := dial(*socket)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer closeConn()
+
+	id, err := findMount(ctx, client, flags.Arg(0))
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	if _, err = client.Unmount(ctx, &rpc.MountIdentifier{Id: id}); err != nil {
+		log.Println(err)
+		return 1
+	}
+	return 0
+}
+
+// findMount returns the id of the mount identified by the given id or mount point.
+func findMount(ctx context.Context, client rpc.FuseFTPClient, idOrMountPoint string) (int32, error) {
+	if id, err := strconv.ParseInt(idOrMountPoint, 10, 32); err == nil {
+		return int32(id), nil
+	}
+	ml, err := client.ListMounts(ctx, &emptypb.Empty{})
+	if err != nil {
+		return 0, err
+	}
+	mp := idOrMountPoint
+	if abs, err := filepath.Abs(mp); err == nil && !isDriveLetter(mp) {
+		mp = abs
+	}
+	for _, m := range ml.Mounts {
+		if m.MountPoint == idOrMountPoint || m.MountPoint == mp {
+			return m.Id.Id, nil
+		}
+	}
+	return 0, fmt.Errorf("found no mount with mount point %s", idOrMountPoint)
+}
+
+func runList(args []string) int {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket of the daemon")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s list [flags]\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return 2
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	client, closeConn, err := dial(*socket)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer closeConn()
+	ml, err := client.ListMounts(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	sort.Slice(ml.Mounts, func(i, j int) bool { return ml.Mounts[i].Id.Id < ml.Mounts[j].Id.Id })
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tMOUNT POINT\tFTP SERVER\tDIRECTORY\tSTATE\tUPTIME\tERROR")
+	for _, m := range ml.Mounts {
+		ap, _ := addrPort(m.FtpServer)
+		state := strings.ToLower(strings.TrimPrefix(m.State.String(), "MOUNT_STATE_"))
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.Id.Id, m.MountPoint, ap, m.Directory, state, m.Uptime.AsDuration().Truncate(time.Second), m.Error)
+	}
+	_ = w.Flush()
+	return 0
+}
+
+func runLogLevel(args []string) int {
+	flags := flag.NewFlagSet("log-level", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket of the daemon")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s log-level [flags] <level> [<mount id or mount point>]\n\nChange the log level of a mount, or of the daemon and all mounts that have no level of their own.\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() < 1 || flags.NArg() > 2 {
+		flags.Usage()
+		return 2
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	client, closeConn, err := dial(*socket)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer closeConn()
+
+	rq := &rpc.SetLogLevelRequest{Level: flags.Arg(0)}
+	if flags.NArg() == 2 {
+		id, err := findMount(ctx, client, flags.Arg(1))
+		if err != nil {
+			log.Println(err)
+			return 1
+		}
+		rq.Id = &rpc.MountIdentifier{Id: id}
+	}
+	if _, err = client.SetLogLevel(ctx, rq); err != nil {
+		log.Println(err)
+		return 1
+	}
+	return 0
+}
+
+func runVersion(args []string) int {
+	flags := flag.NewFlagSet("version", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket of the daemon")
+	daemon := flags.Bool("daemon", false, "also print the version of the daemon")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s version [flags]\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	fmt.Printf("%s %s\n", progName, strings.TrimSpace(version))
+	if !*daemon {
+		return 0
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	client, closeConn, err := dial(*socket)
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	defer closeConn()
+	vi, err := client.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		log.Println(err)
+		return 1
+	}
+	fmt.Printf("daemon %s\n", strings.TrimSpace(vi.Semver))
+	return 0
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		command string
+		cmdArgs []string
+	}{
+		{"command", []string{"mount", "-dir", "x", "127.0.0.1:21", "/mnt/ftp"}, "mount", []string{"-dir", "x", "127.0.0.1:21", "/mnt/ftp"}},
+		{"command without arguments", []string{"list"}, "list", []string{}},
+		{"serve", []string{"serve", "-log-level", "debug"}, "serve", []string{"-log-level", "debug"}},
+		{"socket only", []string{"/tmp/fuseftp.sock"}, "serve", []string{"/tmp/fuseftp.sock"}},
+		{"serve flags", []string{"-log-level", "debug", "/tmp/fuseftp.sock"}, "serve", []string{"-log-level", "debug", "/tmp/fuseftp.sock"}},
+		{"unknown command", []string{"mnt"}, "serve", []string{"mnt"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, args := findCommand(tt.args)
+			assert.Equal(t, tt.command, c.name)
+			assert.Equal(t, tt.cmdArgs, args)
+		})
+	}
+}
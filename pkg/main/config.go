@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gopkg.in/yaml.v3"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// config is the contents of the file given to "serve -config". Settings given as flags take
+// precedence over settings in the file.
+type config struct {
+	Socket      string `yaml:"socket" toml:"socket"`
+	LogLevel    string `yaml:"logLevel" toml:"logLevel"`
+	LogFormat   string `yaml:"logFormat" toml:"logFormat"`
+	MetricsAddr string `yaml:"metricsAddr" toml:"metricsAddr"`
+	StateFile   string `yaml:"stateFile" toml:"stateFile"`
+
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" toml:"shutdownTimeout"`
+
+	// Mounts are created when the daemon starts.
+	Mounts []mountConfig `yaml:"mounts" toml:"mounts"`
+}
+
+// mountConfig declares a mount. It is used both in the config file and by the "mount" command.
+type mountConfig struct {
+	MountPoint       string            `yaml:"mountPoint" toml:"mountPoint"`
+	FtpServer        string            `yaml:"ftpServer" toml:"ftpServer"`
+	Directory        string            `yaml:"directory" toml:"directory"`
+	User             string            `yaml:"user" toml:"user"`
+	Password         string            `yaml:"password" toml:"password"`
+	LogLevel         string            `yaml:"logLevel" toml:"logLevel"`
+	ProxyURL         string            `yaml:"proxyUrl" toml:"proxyUrl"`
+	AutoRemount      bool              `yaml:"autoRemount" toml:"autoRemount"`
+	ReadOnly         bool              `yaml:"readOnly" toml:"readOnly"`
+	AccessChecks     bool              `yaml:"accessChecks" toml:"accessChecks"`
+	ReadTimeout      time.Duration     `yaml:"readTimeout" toml:"readTimeout"`
+	DialTimeout      time.Duration     `yaml:"dialTimeout" toml:"dialTimeout"`
+	ControlTimeout   time.Duration     `yaml:"controlTimeout" toml:"controlTimeout"`
+	DataIdleTimeout  time.Duration     `yaml:"dataIdleTimeout" toml:"dataIdleTimeout"`
+	OperationTimeout time.Duration     `yaml:"operationTimeout" toml:"operationTimeout"`
+	AttrCacheTTL     time.Duration     `yaml:"attrCacheTTL" toml:"attrCacheTTL"`
+	RateLimits       rateLimitsConfig  `yaml:"rateLimits" toml:"rateLimits"`
+	MaxIdle          int32             `yaml:"maxIdleConnections" toml:"maxIdleConnections"`
+	ErrorRules       []errorRuleConfig `yaml:"errorRules" toml:"errorRules"`
+	Fuse             fuseConfig        `yaml:"fuse" toml:"fuse"`
+	Identity         identityConfig    `yaml:"identity" toml:"identity"`
+
+	// UserCredentials maps local uids to the credentials used for their calls.
+	UserCredentials map[string]credentialsConfig `yaml:"userCredentials" toml:"userCredentials"`
+}
+
+type credentialsConfig struct {
+	User     string `yaml:"user" toml:"user"`
+	Password string `yaml:"password" toml:"password"`
+}
+
+// fuseConfig declares the options passed to FUSE. The umask is an octal string, e.g. "022".
+type fuseConfig struct {
+	AllowOther      bool           `yaml:"allowOther" toml:"allowOther"`
+	AllowRoot       *bool          `yaml:"allowRoot" toml:"allowRoot"`
+	UID             *uint32        `yaml:"uid" toml:"uid"`
+	GID             *uint32        `yaml:"gid" toml:"gid"`
+	Umask           string         `yaml:"umask" toml:"umask"`
+	FSName          string         `yaml:"fsname" toml:"fsname"`
+	Subtype         string         `yaml:"subtype" toml:"subtype"`
+	MaxRead         uint32         `yaml:"maxRead" toml:"maxRead"`
+	EntryTimeout    *time.Duration `yaml:"entryTimeout" toml:"entryTimeout"`
+	AttrTimeout     *time.Duration `yaml:"attrTimeout" toml:"attrTimeout"`
+	NegativeTimeout *time.Duration `yaml:"negativeTimeout" toml:"negativeTimeout"`
+	Extra           []string       `yaml:"extra" toml:"extra"`
+}
+
+// identityConfig declares the owner and permissions of files and directories. The modes and
+// the umask are octal strings, e.g. "0644".
+type identityConfig struct {
+	UID           *uint32           `yaml:"uid" toml:"uid"`
+	GID           *uint32           `yaml:"gid" toml:"gid"`
+	FileMode      string            `yaml:"fileMode" toml:"fileMode"`
+	DirMode       string            `yaml:"dirMode" toml:"dirMode"`
+	UseServerMode bool              `yaml:"useServerMode" toml:"useServerMode"`
+	Umask         string            `yaml:"umask" toml:"umask"`
+	Owners        map[string]uint32 `yaml:"owners" toml:"owners"`
+	Groups        map[string]uint32 `yaml:"groups" toml:"groups"`
+}
+
+type rateLimitsConfig struct {
+	Upload         int64 `yaml:"upload" toml:"upload"`
+	Download       int64 `yaml:"download" toml:"download"`
+	HandleUpload   int64 `yaml:"handleUpload" toml:"handleUpload"`
+	HandleDownload int64 `yaml:"handleDownload" toml:"handleDownload"`
+}
+
+type errorRuleConfig struct {
+	Code    int32  `yaml:"code" toml:"code"`
+	Message string `yaml:"message" toml:"message"`
+	Errno   string `yaml:"errno" toml:"errno"`
+}
+
+// readConfig reads a config file. The format is determined by the file extension, which must
+// be .yaml, .yml, or .toml.
+func readConfig(file string) (config, error) {
+	var cfg config
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return cfg, err
+	}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err = dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return cfg, fmt.Errorf("%s: %w", file, err)
+		}
+	case ".toml":
+		md, err := toml.Decode(string(data), &cfg)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: %w", file, err)
+		}
+		if ud := md.Undecoded(); len(ud) > 0 {
+			return cfg, fmt.Errorf("%s: unknown key %q", file, ud[0].String())
+		}
+	default:
+		return cfg, fmt.Errorf("%s: unknown config file format, use .yaml, .yml, or .toml", file)
+	}
+	return cfg, nil
+}
+
+// ftpAddress resolves an FTP server address given as host:port.
+func ftpAddress(hostPort string) (*rpc.AddressAndPort, error) {
+	ta, err := net.ResolveTCPAddr("tcp", hostPort)
+	if err != nil {
+		return nil, err
+	}
+	ap := ta.AddrPort()
+	return &rpc.AddressAndPort{Ip: ap.Addr().Unmap().AsSlice(), Port: int32(ap.Port())}, nil
+}
+
+func optionalDuration(d time.Duration) *durationpb.Duration {
+	if d == 0 {
+		return nil
+	}
+	return durationpb.New(d)
+}
+
+// request returns the MountRequest declared by the mountConfig.
+func (mc *mountConfig) request() (*rpc.MountRequest, error) {
+	if mc.MountPoint == "" {
+		return nil, errors.New("no mount point")
+	}
+	if mc.FtpServer == "" {
+		return nil, errors.New("no FTP server")
+	}
+	ap, err := ftpAddress(mc.FtpServer)
+	if err != nil {
+		return nil, err
+	}
+	readTimeout := mc.ReadTimeout
+	if readTimeout == 0 {
+		readTimeout = defaultReadTimeout
+	}
+	rq := &rpc.MountRequest{
+		MountPoint:       mc.MountPoint,
+		FtpServer:        ap,
+		ReadTimeout:      durationpb.New(readTimeout),
+		Directory:        mc.Directory,
+		LogLevel:         mc.LogLevel,
+		ProxyUrl:         mc.ProxyURL,
+		AutoRemount:      mc.AutoRemount,
+		ReadOnly:         mc.ReadOnly,
+		AccessChecks:     mc.AccessChecks,
+		DialTimeout:      optionalDuration(mc.DialTimeout),
+		ControlTimeout:   optionalDuration(mc.ControlTimeout),
+		DataIdleTimeout:  optionalDuration(mc.DataIdleTimeout),
+		OperationTimeout: optionalDuration(mc.OperationTimeout),
+		AttrCacheTtl:     optionalDuration(mc.AttrCacheTTL),
+	}
+	if mc.User != "" {
+		rq.Credentials = &rpc.Credentials{User: mc.User, Password: mc.Password}
+	}
+	if mc.MaxIdle > 0 {
+		rq.PoolLimits = &rpc.PoolLimits{MaxIdle: mc.MaxIdle}
+	}
+	if rl := mc.RateLimits; rl != (rateLimitsConfig{}) {
+		rq.RateLimits = &rpc.RateLimits{
+			Upload:         rl.Upload,
+			Download:       rl.Download,
+			HandleUpload:   rl.HandleUpload,
+			HandleDownload: rl.HandleDownload,
+		}
+	}
+	if rq.FuseOptions, err = mc.Fuse.options(); err != nil {
+		return nil, err
+	}
+	if rq.Identity, err = mc.Identity.identity(); err != nil {
+		return nil, err
+	}
+	for uid, c := range mc.UserCredentials {
+		id, err := strconv.ParseUint(uid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uid %q in userCredentials: %w", uid, err)
+		}
+		if rq.UserCredentials == nil {
+			rq.UserCredentials = make(map[uint32]*rpc.Credentials)
+		}
+		rq.UserCredentials[uint32(id)] = &rpc.Credentials{User: c.User, Password: c.Password}
+	}
+	for _, er := range mc.ErrorRules {
+		rq.ErrorRules = append(rq.ErrorRules, &rpc.ErrorRule{Code: er.Code, Message: er.Message, Errno: er.Errno})
+	}
+	return rq, nil
+}
+
+// options returns the FuseOptions declared by the fuseConfig, or nil when it declares none.
+func (fc *fuseConfig) options() (*rpc.FuseOptions, error) {
+	fo := &rpc.FuseOptions{
+		AllowOther: fc.AllowOther,
+		AllowRoot:  fc.AllowRoot,
+		Uid:        fc.UID,
+		Gid:        fc.GID,
+		Fsname:     fc.FSName,
+		Subtype:    fc.Subtype,
+		MaxRead:    fc.MaxRead,
+		Extra:      fc.Extra,
+	}
+	var err error
+	if fo.Umask, err = octal("umask", fc.Umask); err != nil {
+		return nil, err
+	}
+	for _, d := range []struct {
+		dst **durationpb.Duration
+		src *time.Duration
+	}{{&fo.EntryTimeout, fc.EntryTimeout}, {&fo.AttrTimeout, fc.AttrTimeout}, {&fo.NegativeTimeout, fc.NegativeTimeout}} {
+		if d.src != nil {
+			*d.dst = durationpb.New(*d.src)
+		}
+	}
+	if proto.Equal(fo, &rpc.FuseOptions{}) {
+		return nil, nil
+	}
+	return fo, nil
+}
+
+// identity returns the Identity declared by the identityConfig, or nil when it declares none.
+func (ic *identityConfig) identity() (*rpc.Identity, error) {
+	id := &rpc.Identity{
+		Uid:           ic.UID,
+		Gid:           ic.GID,
+		UseServerMode: ic.UseServerMode,
+		Owners:        ic.Owners,
+		Groups:        ic.Groups,
+	}
+	var err error
+	if id.FileMode, err = octal("fileMode", ic.FileMode); err != nil {
+		return nil, err
+	}
+	if id.DirMode, err = octal("dirMode", ic.DirMode); err != nil {
+		return nil, err
+	}
+	umask, err := octal("umask", ic.Umask)
+	if err != nil {
+		return nil, err
+	}
+	if umask != nil {
+		id.Umask = *umask
+	}
+	if proto.Equal(id, &rpc.Identity{}) {
+		return nil, nil
+	}
+	return id, nil
+}
+
+// octal parses the given octal string, or returns nil when it is empty.
+func octal(name, s string) (*uint32, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseUint(s, 8, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", name, s, err)
+	}
+	u := uint32(v)
+	return &u, nil
+}
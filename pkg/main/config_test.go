@@ -0,0 +1,282 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func TestReadConfig(t *testing.T) {
+	uid := uint32(1000)
+	want := config{
+		Socket:          "/run/fuseftp.sock",
+		LogLevel:        "debug",
+		ShutdownTimeout: 10 * time.Second,
+		Mounts: []mountConfig{{
+			MountPoint:  "/mnt/ftp",
+			FtpServer:   "127.0.0.1:2121",
+			ReadTimeout: 5 * time.Second,
+			RateLimits:  rateLimitsConfig{Download: 1024},
+			Identity:    identityConfig{UID: &uid, FileMode: "0644"},
+			ErrorRules:  []errorRuleConfig{{Code: 550, Message: "quota", Errno: "ENOSPC"}},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    config
+		wantErr string
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `socket: /run/fuseftp.sock
+logLevel: debug
+shutdownTimeout: 10s
+mounts:
+  - mountPoint: /mnt/ftp
+    ftpServer: 127.0.0.1:2121
+    readTimeout: 5s
+    rateLimits:
+      download: 1024
+    identity:
+      uid: 1000
+      fileMode: "0644"
+    errorRules:
+      - code: 550
+        message: quota
+        errno: ENOSPC
+`,
+			want: want,
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: `socket = "/run/fuseftp.sock"
+logLevel = "debug"
+shutdownTimeout = "10s"
+
+[[mounts]]
+mountPoint = "/mnt/ftp"
+ftpServer = "127.0.0.1:2121"
+readTimeout = "5s"
+rateLimits = { download = 1024 }
+identity = { uid = 1000, fileMode = "0644" }
+errorRules = [{ code = 550, message = "quota", errno = "ENOSPC" }]
+`,
+			want: want,
+		},
+		{
+			name:    "empty yaml",
+			file:    "config.yml",
+			content: "",
+		},
+		{
+			name:    "unknown yaml key",
+			file:    "config.yaml",
+			content: "socket: /run/fuseftp.sock\nmounts:\n  - mountPoint: /mnt/ftp\n    mountpoint: /mnt/other\n",
+			wantErr: "field mountpoint not found",
+		},
+		{
+			name:    "unknown toml key",
+			file:    "config.toml",
+			content: "sockett = \"/run/fuseftp.sock\"\n",
+			wantErr: `unknown key "sockett"`,
+		},
+		{
+			name:    "unknown format",
+			file:    "config.json",
+			content: "{}",
+			wantErr: "unknown config file format",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0o600))
+			cfg, err := readConfig(file)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg)
+		})
+	}
+}
+
+func TestMountConfigRequest(t *testing.T) {
+	u32 := func(v uint32) *uint32 { return &v }
+	yes := true
+	minute := time.Minute
+	base := func() mountConfig {
+		return mountConfig{MountPoint: "/mnt/ftp", FtpServer: "127.0.0.1:2121"}
+	}
+	baseRequest := func() *rpc.MountRequest {
+		return &rpc.MountRequest{
+			MountPoint:  "/mnt/ftp",
+			FtpServer:   &rpc.AddressAndPort{Ip: []byte{127, 0, 0, 1}, Port: 2121},
+			ReadTimeout: durationpb.New(defaultReadTimeout),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(mc *mountConfig)
+		want    func(rq *rpc.MountRequest)
+		wantErr string
+	}{
+		{
+			name:   "defaults",
+			modify: func(mc *mountConfig) {},
+			want:   func(rq *rpc.MountRequest) {},
+		},
+		{
+			name: "credentials and limits",
+			modify: func(mc *mountConfig) {
+				mc.User, mc.Password = "alice", "secret"
+				mc.ReadTimeout, mc.DialTimeout = 5*time.Second, time.Second
+				mc.MaxIdle = 4
+				mc.RateLimits.Upload = 2048
+				mc.UserCredentials = map[string]credentialsConfig{"1001": {User: "bob", Password: "pw"}}
+				mc.ErrorRules = []errorRuleConfig{{Code: 550, Errno: "EACCES"}}
+			},
+			want: func(rq *rpc.MountRequest) {
+				rq.Credentials = &rpc.Credentials{User: "alice", Password: "secret"}
+				rq.ReadTimeout, rq.DialTimeout = durationpb.New(5*time.Second), durationpb.New(time.Second)
+				rq.PoolLimits = &rpc.PoolLimits{MaxIdle: 4}
+				rq.RateLimits = &rpc.RateLimits{Upload: 2048}
+				rq.UserCredentials = map[uint32]*rpc.Credentials{1001: {User: "bob", Password: "pw"}}
+				rq.ErrorRules = []*rpc.ErrorRule{{Code: 550, Errno: "EACCES"}}
+			},
+		},
+		{
+			name: "fuse options",
+			modify: func(mc *mountConfig) {
+				mc.Fuse = fuseConfig{AllowRoot: &yes, UID: u32(1000), Umask: "027", EntryTimeout: &minute, Extra: []string{"ro"}}
+			},
+			want: func(rq *rpc.MountRequest) {
+				rq.FuseOptions = &rpc.FuseOptions{AllowRoot: &yes, Uid: u32(1000), Umask: u32(0o27), EntryTimeout: durationpb.New(time.Minute), Extra: []string{"ro"}}
+			},
+		},
+		{
+			name: "identity",
+			modify: func(mc *mountConfig) {
+				mc.Identity = identityConfig{GID: u32(100), FileMode: "0640", DirMode: "0750", Umask: "002", Owners: map[string]uint32{"ftp": 1000}}
+			},
+			want: func(rq *rpc.MountRequest) {
+				rq.Identity = &rpc.Identity{Gid: u32(100), FileMode: u32(0o640), DirMode: u32(0o750), Umask: 0o2, Owners: map[string]uint32{"ftp": 1000}}
+			},
+		},
+		{
+			name:    "no mount point",
+			modify:  func(mc *mountConfig) { mc.MountPoint = "" },
+			wantErr: "no mount point",
+		},
+		{
+			name:    "no FTP server",
+			modify:  func(mc *mountConfig) { mc.FtpServer = "" },
+			wantErr: "no FTP server",
+		},
+		{
+			name:    "invalid umask",
+			modify:  func(mc *mountConfig) { mc.Fuse.Umask = "089" },
+			wantErr: `invalid umask "089"`,
+		},
+		{
+			name:    "invalid file mode",
+			modify:  func(mc *mountConfig) { mc.Identity.FileMode = "rw-r--r--" },
+			wantErr: `invalid fileMode "rw-r--r--"`,
+		},
+		{
+			name:    "invalid uid",
+			modify:  func(mc *mountConfig) { mc.UserCredentials = map[string]credentialsConfig{"bob": {}} },
+			wantErr: `invalid uid "bob"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mc := base()
+			tt.modify(&mc)
+			rq, err := mc.request()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			want := baseRequest()
+			tt.want(want)
+			assert.True(t, proto.Equal(want, rq), "got %v, want %v", rq, want)
+		})
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	cfg := &config{
+		Socket:          "/run/config.sock",
+		LogLevel:        "debug",
+		StateFile:       "/var/lib/fuseftp/state.json",
+		ShutdownTimeout: time.Minute,
+	}
+	tests := []struct {
+		name string
+		args []string
+		want map[string]string
+	}{
+		{
+			name: "config",
+			want: map[string]string{
+				"socket":           "/run/config.sock",
+				"log-level":        "debug",
+				"log-format":       "text",
+				"state-file":       "/var/lib/fuseftp/state.json",
+				"shutdown-timeout": "1m0s",
+			},
+		},
+		{
+			name: "flags take precedence",
+			args: []string{"-socket", "/run/flag.sock", "-log-level", "warn", "-shutdown-timeout", "5s"},
+			want: map[string]string{
+				"socket":           "/run/flag.sock",
+				"log-level":        "warn",
+				"log-format":       "text",
+				"state-file":       "/var/lib/fuseftp/state.json",
+				"shutdown-timeout": "5s",
+			},
+		},
+		{
+			name: "explicitly given default",
+			args: []string{"-log-level", "info"},
+			want: map[string]string{"log-level": "info"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+			flags.String("socket", "/tmp/fuseftp.sock", "")
+			flags.String("log-level", "info", "")
+			flags.String("log-format", "text", "")
+			flags.String("metrics-addr", "", "")
+			flags.String("state-file", "", "")
+			flags.Duration("shutdown-timeout", defaultShutdownTimeout, "")
+			require.NoError(t, flags.Parse(tt.args))
+			require.NoError(t, applyConfig(flags, cfg))
+			for name, v := range tt.want {
+				assert.Equal(t, v, flags.Lookup(name).Value.String(), name)
+			}
+		})
+	}
+}
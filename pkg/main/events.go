@@ -0,0 +1,107 @@
+package main
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// eventBufferSize is the number of events that are buffered for each subscriber. Events
+// are discarded when the buffer is full.
+const eventBufferSize = 256
+
+type subscriber struct {
+	id      int32 // -1 means all mounts
+	ch      chan *rpc.MountEvent
+	dropped uint64
+}
+
+// eventHub distributes the events of all mounts to the subscribers.
+type eventHub struct {
+	sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func (h *eventHub) subscribe(id int32) *subscriber {
+	sub := &subscriber{id: id, ch: make(chan *rpc.MountEvent, eventBufferSize)}
+	h.Lock()
+	if h.subscribers == nil {
+		h.subscribers = make(map[*subscriber]struct{})
+	}
+	h.subscribers[sub] = struct{}{}
+	h.Unlock()
+	return sub
+}
+
+func (h *eventHub) unsubscribe(sub *subscriber) {
+	h.Lock()
+	delete(h.subscribers, sub)
+	h.Unlock()
+}
+
+// publish sends the event to all subscribers of the given mount without blocking.
+func (h *eventHub) publish(id int32, e fs.Event) {
+	me := &rpc.MountEvent{
+		Id: &rpc.MountIdentifier{Id: id},
+		// The fs.EventType values are equal to the rpc.EventType values.
+		Type:    rpc.EventType(e.Type),
+		Time:    timestamppb.New(e.Time),
+		Path:    e.Path,
+		Message: e.Message,
+	}
+	if e.Address.IsValid() {
+		me.FtpServer = &rpc.AddressAndPort{Ip: e.Address.Addr().AsSlice(), Port: int32(e.Address.Port())}
+	}
+	h.Lock()
+	for sub := range h.subscribers {
+		if sub.id >= 0 && sub.id != id {
+			continue
+		}
+		sme := me
+		if sub.dropped > 0 {
+			sme = proto.Clone(me).(*rpc.MountEvent)
+			sme.Dropped = sub.dropped
+		}
+		select {
+		case sub.ch <- sme:
+			sub.dropped = 0
+		default:
+			sub.dropped++
+		}
+	}
+	h.Unlock()
+}
+
+func (s *service) WatchEvents(rq *rpc.WatchEventsRequest, stream rpc.FuseFTP_WatchEventsServer) error {
+	id := int32(-1)
+	if rq.Id != nil {
+		id = rq.Id.Id
+		s.Lock()
+		_, ok := s.mounts[id]
+		s.Unlock()
+		if !ok {
+			return status.Errorf(codes.NotFound, "found no mount with id %d", id)
+		}
+	}
+	sub := s.events.subscribe(id)
+	defer s.events.unsubscribe(sub)
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.closing:
+			return nil
+		case me := <-sub.ch:
+			if err := stream.Send(me); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func TestEventHubSlowSubscriber(t *testing.T) {
+	var h eventHub
+	slow := h.subscribe(1)
+	other := h.subscribe(2)
+	all := h.subscribe(-1)
+
+	// Publishing never blocks. The events that don't fit the buffer are dropped and counted.
+	for i := 0; i < eventBufferSize+3; i++ {
+		h.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+		<-all.ch
+	}
+	assert.Len(t, slow.ch, eventBufferSize)
+	assert.Equal(t, uint64(3), slow.dropped)
+	assert.Empty(t, other.ch)
+
+	// The first event delivered after the drop reports the number of dropped events
+	for len(slow.ch) > 0 {
+		assert.Zero(t, (<-slow.ch).Dropped)
+	}
+	h.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+	h.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+	assert.Equal(t, uint64(3), (<-slow.ch).Dropped)
+	assert.Zero(t, (<-slow.ch).Dropped)
+	assert.Zero(t, (<-all.ch).Dropped)
+
+	h.unsubscribe(slow)
+	h.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+	assert.Empty(t, slow.ch)
+}
+
+// fakeEventStream is an rpc.FuseFTP_WatchEventsServer that passes the events that are sent to
+// a channel. Its other methods must not be called.
+type fakeEventStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	events  chan *rpc.MountEvent
+	sendErr error
+}
+
+func (s *fakeEventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeEventStream) Send(me *rpc.MountEvent) error {
+	if s.sendErr != nil {
+		return s.sendErr
+	}
+	s.events <- me
+	return nil
+}
+
+func TestWatchEventsEnds(t *testing.T) {
+	s := newService(context.Background())
+	addFakeMount(s, 1)
+
+	// watch starts a stream, and waits for its subscription to be registered
+	watch := func(ctx context.Context, sendErr error) (*fakeEventStream, chan error) {
+		stream := &fakeEventStream{ctx: ctx, events: make(chan *rpc.MountEvent, 1), sendErr: sendErr}
+		errCh := make(chan error, 1)
+		go func() { errCh <- s.WatchEvents(&rpc.WatchEventsRequest{Id: &rpc.MountIdentifier{Id: 1}}, stream) }()
+		require.Eventually(t, func() bool {
+			s.events.Lock()
+			defer s.events.Unlock()
+			return len(s.events.subscribers) == 1
+		}, time.Second, time.Millisecond)
+		return stream, errCh
+	}
+	ended := func(errCh chan error) error {
+		select {
+		case err := <-errCh:
+			s.events.Lock()
+			assert.Empty(t, s.events.subscribers)
+			s.events.Unlock()
+			return err
+		case <-time.After(time.Second):
+			t.Fatal("the stream did not end")
+			return nil
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	_, errCh := watch(ctx, nil)
+	cancel()
+	assert.NoError(t, ended(errCh))
+
+	// A stream that cannot be sent to ends with the error
+	sendErr := errors.New("broken stream")
+	_, errCh = watch(context.Background(), sendErr)
+	s.events.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+	assert.ErrorIs(t, ended(errCh), sendErr)
+
+	stream, errCh := watch(context.Background(), nil)
+	s.events.publish(1, fs.Event{Type: fs.EventTransferError, Time: time.Now()})
+	assert.Equal(t, int32(1), (<-stream.events).Id.Id)
+	s.closeStreams()
+	assert.NoError(t, ended(errCh))
+}
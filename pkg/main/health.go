@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// mountServiceName returns the name used in health checks of the mount with the given id.
+func mountServiceName(id int32) string {
+	return fmt.Sprintf("%s/mount/%d", rpc.FuseFTP_ServiceDesc.ServiceName, id)
+}
+
+type mountHealth struct {
+	mounted   bool
+	reachable bool
+}
+
+// healthTracker maintains the status reported by the grpc.health.v1 service. The overall
+// status, and the status of the FuseFTP service, is SERVING while the daemon runs. The
+// status of each mount is SERVING while its FUSE host is mounted and its FTP server is
+// reachable.
+type healthTracker struct {
+	sync.Mutex
+	*health.Server
+	mounts map[int32]*mountHealth
+}
+
+func newHealthTracker() *healthTracker {
+	ht := &healthTracker{
+		Server: health.NewServer(),
+		mounts: make(map[int32]*mountHealth),
+	}
+	ht.SetServingStatus(rpc.FuseFTP_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	return ht
+}
+
+// addMount starts tracking the given mount. It is not serving until it has been mounted.
+func (ht *healthTracker) addMount(id int32) {
+	ht.Lock()
+	ht.mounts[id] = &mountHealth{reachable: true}
+	ht.Unlock()
+	ht.SetServingStatus(mountServiceName(id), healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+// restartMount resets the status of a tracked mount that is about to be mounted again.
+func (ht *healthTracker) restartMount(id int32) {
+	ht.Lock()
+	if mh, ok := ht.mounts[id]; ok {
+		*mh = mountHealth{reachable: true}
+	}
+	ht.Unlock()
+}
+
+// removeMount stops tracking the given mount. The health.Server cannot forget a service, so its
+// status becomes SERVICE_UNKNOWN, which is what Watch reports for services that it doesn't know,
+// and Check reports it as not found.
+func (ht *healthTracker) removeMount(id int32) {
+	ht.Lock()
+	delete(ht.mounts, id)
+	ht.Unlock()
+	ht.SetServingStatus(mountServiceName(id), healthpb.HealthCheckResponse_SERVICE_UNKNOWN)
+}
+
+// Check returns the status of the given service, or a NotFound error when the service is
+// unknown or has been removed.
+func (ht *healthTracker) Check(ctx context.Context, in *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	rsp, err := ht.Server.Check(ctx, in)
+	if err == nil && rsp.Status == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return rsp, err
+}
+
+// update changes the status of a mount based on the given event.
+func (ht *healthTracker) update(id int32, e fs.Event) {
+	ht.Lock()
+	mh, ok := ht.mounts[id]
+	if !ok {
+		ht.Unlock()
+		return
+	}
+	switch e.Type {
+	case fs.EventMounted:
+		mh.mounted = true
+	case fs.EventUnmounted:
+		mh.mounted = false
+	case fs.EventConnectionLost:
+		mh.reachable = false
+	case fs.EventConnectionRestored:
+		mh.reachable = true
+	default:
+		ht.Unlock()
+		return
+	}
+	st := healthpb.HealthCheckResponse_NOT_SERVING
+	if mh.mounted && mh.reachable {
+		st = healthpb.HealthCheckResponse_SERVING
+	}
+	// The status is set while locked to ensure that the order of updates is retained.
+	ht.SetServingStatus(mountServiceName(id), st)
+	ht.Unlock()
+}
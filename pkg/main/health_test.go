@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+)
+
+func TestHealthTracker(t *testing.T) {
+	ctx := context.Background()
+	ht := newHealthTracker()
+	check := func() (healthpb.HealthCheckResponse_ServingStatus, error) {
+		rsp, err := ht.Check(ctx, &healthpb.HealthCheckRequest{Service: mountServiceName(1)})
+		return rsp.GetStatus(), err
+	}
+
+	_, err := check()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	ht.addMount(1)
+	st, err := check()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, st)
+
+	ht.update(1, fs.Event{Type: fs.EventMounted})
+	st, err = check()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, st)
+
+	ht.update(1, fs.Event{Type: fs.EventConnectionLost})
+	st, err = check()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, st)
+
+	// A removed mount is unknown, and events that arrive after the removal are ignored
+	ht.removeMount(1)
+	ht.update(1, fs.Event{Type: fs.EventConnectionRestored})
+	_, err = check()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func configureLogging(level, format string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	logrus.SetLevel(lvl)
+	switch format {
+	case "", "text":
+		logrus.SetFormatter(&logrus.TextFormatter{})
+	case "json":
+		logrus.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return fmt.Errorf("invalid log format %q", format)
+	}
+	return nil
+}
+
+// newMountLogger returns a logger with the given level that writes to the same output, using the
+// same format, as the standard logger.
+func newMountLogger(level logrus.Level) *logrus.Logger {
+	std := logrus.StandardLogger()
+	l := logrus.New()
+	l.SetOutput(std.Out)
+	l.SetFormatter(std.Formatter)
+	l.SetReportCaller(std.ReportCaller)
+	l.SetLevel(level)
+	return l
+}
+
+func (s *service) SetLogLevel(_ context.Context, rq *rpc.SetLogLevelRequest) (*emptypb.Empty, error) {
+	lvl, err := logrus.ParseLevel(rq.Level)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.Lock()
+	defer s.Unlock()
+	if rq.Id == nil {
+		logrus.SetLevel(lvl)
+		for _, m := range s.mounts {
+			if m.logLevel == "" {
+				m.logger.SetLevel(lvl)
+			}
+		}
+		return &emptypb.Empty{}, nil
+	}
+	m, ok := s.mounts[rq.Id.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "found no mount with id %d", rq.Id.Id)
+	}
+	m.logger.SetLevel(lvl)
+	m.logLevel = lvl.String()
+	s.saveState()
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func TestSetLogLevel(t *testing.T) {
+	orig := logrus.GetLevel()
+	logrus.SetLevel(logrus.InfoLevel)
+	t.Cleanup(func() { logrus.SetLevel(orig) })
+
+	ctx := context.Background()
+	s := newService(ctx)
+	for id := int32(1); id <= 3; id++ {
+		addFakeMount(s, id)
+		s.mounts[id].logger = newMountLogger(logrus.InfoLevel)
+	}
+	levels := func() []logrus.Level {
+		return []logrus.Level{logrus.GetLevel(), s.mounts[1].logger.GetLevel(), s.mounts[2].logger.GetLevel(), s.mounts[3].logger.GetLevel()}
+	}
+
+	// The level of one mount leaves the daemon and the other mounts alone
+	_, err := s.SetLogLevel(ctx, &rpc.SetLogLevelRequest{Id: &rpc.MountIdentifier{Id: 2}, Level: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, []logrus.Level{logrus.InfoLevel, logrus.InfoLevel, logrus.DebugLevel, logrus.InfoLevel}, levels())
+	assert.Equal(t, "debug", s.mounts[2].currentRequest().LogLevel)
+	assert.Empty(t, s.mounts[1].currentRequest().LogLevel)
+
+	// The level of the daemon applies to the mounts that have no level of their own
+	_, err = s.SetLogLevel(ctx, &rpc.SetLogLevelRequest{Level: "warn"})
+	require.NoError(t, err)
+	assert.Equal(t, []logrus.Level{logrus.WarnLevel, logrus.WarnLevel, logrus.DebugLevel, logrus.WarnLevel}, levels())
+
+	_, err = s.SetLogLevel(ctx, &rpc.SetLogLevelRequest{Id: &rpc.MountIdentifier{Id: 4}, Level: "debug"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.SetLogLevel(ctx, &rpc.SetLogLevelRequest{Id: &rpc.MountIdentifier{Id: 1}, Level: "loud"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []logrus.Level{logrus.WarnLevel, logrus.WarnLevel, logrus.DebugLevel, logrus.WarnLevel}, levels())
+}
@@ -4,18 +4,18 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"log"
 	"math"
-	"net"
 	"net/netip"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/datawire/go-fuseftp/pkg/fs"
@@ -25,10 +25,105 @@ import (
 //go:embed version.txt
 var version string
 
+// The delay before the first attempt to remount a dead mount, and the maximum delay between
+// attempts.
+const (
+	remountMinBackoff = time.Second
+	remountMaxBackoff = time.Minute
+)
+
 type mount struct {
+	id         int32
 	mountPoint string
 	cancel     context.CancelFunc
-	ftpClient  fs.FTPClient
+	request    *rpc.MountRequest
+	started    time.Time
+
+	// logger is the logger of the mount, so that its level can be changed individually, and log
+	// is an entry of that logger that identifies the mount.
+	logger *logrus.Logger
+	log    *logrus.Entry
+
+	// The remaining fields are protected by the service mutex.
+
+	// ftpClient is the client of the current FUSE host. It is replaced when the mount is remounted.
+	ftpClient fs.FTPClient
+
+	// stop stops the current FUSE host. It is nil when the mount is dead.
+	stop func()
+
+	// ftpServer is the address of the FTP server.
+	ftpServer netip.AddrPort
+
+	// rateLimits are the current bandwidth limits.
+	rateLimits *rpc.RateLimits
+
+	// logLevel is the log level of the mount, or empty when it uses the level of the daemon.
+	logLevel string
+
+	// updateLock serializes calls to UpdateMount.
+	updateLock sync.Mutex
+
+	state rpc.MountState
+
+	// err is the reason why the mount died, or the error from the last remount attempt.
+	err string
+
+	// fromConfig is set when the mount is declared in the config file. Such mounts are not
+	// recorded in the state file, because the config file creates them when the daemon starts.
+	fromConfig bool
+}
+
+// info returns the MountInfo of the mount. The service must be locked when this method is called.
+func (m *mount) info() *rpc.MountInfo {
+	return &rpc.MountInfo{
+		Id:         &rpc.MountIdentifier{Id: m.id},
+		MountPoint: m.mountPoint,
+		FtpServer: &rpc.AddressAndPort{
+			Ip:   m.ftpServer.Addr().AsSlice(),
+			Port: int32(m.ftpServer.Port()),
+		},
+		Directory: m.request.Directory,
+		Options:   redacted(m.request),
+		Uptime:    durationpb.New(time.Since(m.started)),
+		State:     m.state,
+		Error:     m.err,
+	}
+}
+
+// redacted returns the given request, or a copy of it without the password when it has one.
+func redacted(rq *rpc.MountRequest) *rpc.MountRequest {
+	hasPassword := rq.GetCredentials().GetPassword() != ""
+	for _, c := range rq.UserCredentials {
+		hasPassword = hasPassword || c.GetPassword() != ""
+	}
+	if !hasPassword {
+		return rq
+	}
+	rq = proto.Clone(rq).(*rpc.MountRequest)
+	if rq.Credentials != nil {
+		rq.Credentials.Password = ""
+	}
+	for _, c := range rq.UserCredentials {
+		if c != nil {
+			c.Password = ""
+		}
+	}
+	return rq
+}
+
+// currentRequest returns a copy of the request of the mount, updated with the FTP server and
+// the rate limits that have been set since the mount was created. The service must be locked
+// when this method is called.
+func (m *mount) currentRequest() *rpc.MountRequest {
+	rq := proto.Clone(m.request).(*rpc.MountRequest)
+	rq.FtpServer = &rpc.AddressAndPort{
+		Ip:   m.ftpServer.Addr().AsSlice(),
+		Port: int32(m.ftpServer.Port()),
+	}
+	rq.RateLimits = m.rateLimits
+	rq.LogLevel = m.logLevel
+	return rq
 }
 
 // service represents the state of the Telepresence Daemon
@@ -38,6 +133,27 @@ type service struct {
 	nextID int32
 	mounts map[int32]*mount
 	ctx    context.Context
+	events eventHub
+
+	// tracerProvider is used by all mounts
+	tracerProvider *tracerProvider
+
+	health *healthTracker
+
+	// closing is closed when the daemon shuts down. It ends all event streams.
+	closing     chan struct{}
+	closingOnce sync.Once
+
+	// stateFile is where the active mounts are recorded. Empty when no state is kept.
+	stateFile string
+
+	// stopping is set when the daemon shuts down. The mounts that are removed then are
+	// kept in the state file so that they are restored when the daemon starts again.
+	stopping bool
+
+	// remountBackoff returns the delay before the given attempt, counted from zero, to remount
+	// a dead mount.
+	remountBackoff func(attempt int) time.Duration
 }
 
 func (s *service) Version(context.Context, *emptypb.Empty) (*rpc.VersionInfo, error) {
@@ -45,20 +161,25 @@ func (s *service) Version(context.Context, *emptypb.Empty) (*rpc.VersionInfo, er
 }
 
 func addrPort(ap *rpc.AddressAndPort) (netip.AddrPort, error) {
-	ip, ok := netip.AddrFromSlice(ap.Ip)
-	if !ok || ap.Port < 1 || ap.Port > math.MaxUint16 {
+	ip, ok := netip.AddrFromSlice(ap.GetIp())
+	port := ap.GetPort()
+	if !ok || port < 1 || port > math.MaxUint16 {
 		return netip.AddrPort{}, status.Errorf(codes.InvalidArgument, "invalid address")
 	}
-	return netip.AddrPortFrom(ip, uint16(ap.Port)), nil
+	return netip.AddrPortFrom(ip, uint16(port)), nil
 }
 
 func (s *service) Mount(_ context.Context, rq *rpc.MountRequest) (*rpc.MountIdentifier, error) {
+	return s.mount(rq, false)
+}
+
+// mount creates a mount. The fromConfig argument is set for the mounts that are declared in the
+// config file.
+func (s *service) mount(rq *rpc.MountRequest, fromConfig bool) (*rpc.MountIdentifier, error) {
 	if rq.LogLevel != "" {
-		lvl, err := logrus.ParseLevel(rq.LogLevel)
-		if err != nil {
+		if _, err := logrus.ParseLevel(rq.LogLevel); err != nil {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		logrus.SetLevel(lvl)
 	}
 
 	s.Lock()
@@ -66,6 +187,12 @@ func (s *service) Mount(_ context.Context, rq *rpc.MountRequest) (*rpc.MountIden
 
 	for _, m := range s.mounts {
 		if m.mountPoint == rq.MountPoint {
+			if m.state == rpc.MountState_MOUNT_STATE_DEAD || m.state == rpc.MountState_MOUNT_STATE_FAILED {
+				// A dead mount is replaced by the new one
+				delete(s.mounts, m.id)
+				go m.cancel()
+				continue
+			}
 			return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("directory %q is already mounted", rq.MountPoint))
 		}
 	}
@@ -74,32 +201,174 @@ func (s *service) Mount(_ context.Context, rq *rpc.MountRequest) (*rpc.MountIden
 	if err != nil {
 		return nil, err
 	}
+	id := s.nextID
+	m, ctx, cancel := s.newMount(id, rq, ap)
+	s.health.addMount(id)
+	if m.ftpClient, m.stop, err = s.mountFS(ctx, m, m.request, ap); err != nil {
+		cancel()
+		s.health.removeMount(id)
+		return nil, err
+	}
+	m.fromConfig = fromConfig
+	s.mounts[id] = m
+	s.nextID++
+	s.saveState()
+	return &rpc.MountIdentifier{Id: id}, nil
+}
+
+// newMount creates a mount that has not been started. It returns the mount, and the context and
+// cancel function of the mount. The mount's cancel function removes the mount from the service
+// and stops it.
+func (s *service) newMount(id int32, rq *rpc.MountRequest, ap netip.AddrPort) (*mount, context.Context, context.CancelFunc) {
 	ctx, cancel := context.WithCancel(s.ctx)
-	fi, err := fs.NewFTPClient(ctx, ap, rq.Directory, rq.ReadTimeout.AsDuration())
+	m := &mount{
+		id:         id,
+		mountPoint: rq.MountPoint,
+		request:    proto.Clone(rq).(*rpc.MountRequest),
+		started:    time.Now(),
+		ftpServer:  ap,
+		rateLimits: rq.RateLimits,
+		state:      rpc.MountState_MOUNT_STATE_MOUNTED,
+	}
+	lvl := logrus.GetLevel()
+	if l, err := logrus.ParseLevel(rq.LogLevel); err == nil {
+		lvl = l
+		m.logLevel = rq.LogLevel
+	}
+	m.logger = newMountLogger(lvl)
+	m.log = m.logger.WithFields(logrus.Fields{"mount_id": id, "mount_point": m.mountPoint})
+	m.cancel = func() {
+		s.Lock()
+		if s.mounts[id] == m {
+			delete(s.mounts, id)
+			s.saveState()
+		}
+		stop := m.stop
+		m.stop = nil
+		s.Unlock()
+		if stop != nil {
+			stop()
+		}
+		cancel()
+		s.health.removeMount(id)
+	}
+	return m, ctx, cancel
+}
+
+// mountFS creates an FTP client and a FUSE host for the given mount, using the given request
+// and FTP server, and starts the host. It returns the client and a function that stops the host.
+func (s *service) mountFS(ctx context.Context, m *mount, rq *rpc.MountRequest, ap netip.AddrPort) (fs.FTPClient, func(), error) {
+	ers, err := errorRules(rq.ErrorRules)
+	if err != nil {
+		return nil, nil, err
+	}
+	mo := mountOptions(rq.FuseOptions)
+	if err = mo.Validate(); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	id := identity(rq.Identity)
+	if err = id.Validate(); err != nil {
+		return nil, nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	var stop func()
+	fi, err := fs.NewFTPClient(ctx, ap, rq.Directory, rq.ReadTimeout.AsDuration(),
+		fs.WithEventHandler(func(e fs.Event) {
+			s.health.update(m.id, e)
+			s.events.publish(m.id, e)
+			if e.Type == fs.EventDead {
+				go s.mountDied(m, stop, e.Message)
+			}
+		}),
+		fs.WithLogger(m.log),
+		fs.WithTracerProvider(s.tracerProvider),
+		fs.WithErrorRules(ers...),
+		fs.WithProxy(rq.ProxyUrl),
+		fs.WithRateLimits(rateLimits(rq.RateLimits)),
+		fs.WithCredentials(ftpCredentials(rq.Credentials)),
+		fs.WithPoolLimits(poolLimits(rq.PoolLimits)),
+		fs.WithReadOnly(rq.ReadOnly),
+		fs.WithIdentity(id),
+		fs.WithUserCredentials(userCredentials(rq.UserCredentials)),
+		fs.WithAttrCacheTTL(rq.AttrCacheTtl.AsDuration()),
+		fs.WithAccessChecks(rq.AccessChecks),
+		fs.WithTimeouts(timeouts(rq)))
 	if err != nil {
 		cancel()
-		return nil, status.Errorf(codes.Internal, err.Error())
+		return nil, nil, status.Errorf(codes.Internal, err.Error())
+	}
+	host := fs.NewHostWithOptions(fi, rq.MountPoint, mo)
+	stop = func() {
+		host.Stop()
+		cancel()
 	}
-	host := fs.NewHost(fi, rq.MountPoint)
 	if err := host.Start(ctx, 5*time.Second); err != nil {
 		cancel()
-		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		return nil, nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	return fi, stop, nil
+}
+
+// mountDied is called when the FUSE host of the given mount has been unmounted by something
+// other than an Unmount call. The mount is marked as dead, or remounted if its request
+// says so.
+func (s *service) mountDied(m *mount, stop func(), reason string) {
+	stop()
+	s.Lock()
+	if s.mounts[m.id] != m || m.stop == nil {
+		// Unmounted while dying
+		s.Unlock()
+		return
 	}
+	m.stop = nil
+	m.err = reason
+	if !m.request.AutoRemount {
+		m.state = rpc.MountState_MOUNT_STATE_DEAD
+		s.Unlock()
+		return
+	}
+	m.state = rpc.MountState_MOUNT_STATE_REMOUNTING
+	s.Unlock()
+	s.remount(m)
+}
 
-	id := s.nextID
-	s.mounts[id] = &mount{
-		mountPoint: rq.MountPoint,
-		ftpClient:  fi,
-		cancel: func() {
-			s.Lock()
-			delete(s.mounts, id)
+// remount mounts a dead mount again, using the same id and the current FTP server. Failed
+// attempts are retried with an exponential backoff until the mount is unmounted.
+func (s *service) remount(m *mount) {
+	for attempt := 0; ; attempt++ {
+		time.Sleep(s.remountBackoff(attempt))
+		s.Lock()
+		if s.mounts[m.id] != m {
 			s.Unlock()
-			host.Stop()
-			cancel()
-		},
+			return
+		}
+		rq := m.currentRequest()
+		ap := m.ftpServer
+		s.Unlock()
+
+		s.health.restartMount(m.id)
+		fi, stop, err := s.mountFS(s.ctx, m, rq, ap)
+		s.Lock()
+		if s.mounts[m.id] != m {
+			s.Unlock()
+			if err == nil {
+				stop()
+			}
+			return
+		}
+		if err == nil {
+			m.ftpClient = fi
+			m.stop = stop
+			m.state = rpc.MountState_MOUNT_STATE_MOUNTED
+			m.err = ""
+			s.Unlock()
+			m.log.Info("remounted")
+			return
+		}
+		m.err = err.Error()
+		s.Unlock()
+		m.log.Errorf("remount failed: %v", err)
 	}
-	s.nextID++
-	return &rpc.MountIdentifier{Id: id}, nil
 }
 
 func (s *service) Unmount(_ context.Context, rq *rpc.MountIdentifier) (*emptypb.Empty, error) {
@@ -124,30 +393,229 @@ func (s *service) SetFtpServer(_ context.Context, rq *rpc.SetFtpServerRequest) (
 	if err != nil {
 		return nil, err
 	}
-	if err = m.ftpClient.SetAddress(ap); err != nil {
-		return nil, err
+	s.Lock()
+	fc := m.ftpClient
+	s.Unlock()
+	// A mount that failed to restore has no client. Its new address is used when it is remounted.
+	if fc != nil {
+		if err = fc.SetAddress(ap); err != nil {
+			return nil, err
+		}
 	}
+	s.Lock()
+	m.ftpServer = ap
+	s.saveState()
+	s.Unlock()
 	return &emptypb.Empty{}, err
 }
 
-func main() {
-	if len(os.Args) != 2 {
-		log.Fatalf("Usage: %s <path to unix socket>\n", os.Args[0])
+func (s *service) ListMounts(context.Context, *emptypb.Empty) (*rpc.MountList, error) {
+	s.Lock()
+	ml := &rpc.MountList{Mounts: make([]*rpc.MountInfo, 0, len(s.mounts))}
+	for _, m := range s.mounts {
+		ml.Mounts = append(ml.Mounts, m.info())
 	}
-	// Yes, this works on Windows too
-	sa, err := net.ResolveUnixAddr("unix", os.Args[1])
-	if err != nil {
-		log.Fatalf("Failed to resolve unix socket address: %v\n", err)
+	s.Unlock()
+	sort.Slice(ml.Mounts, func(i, j int) bool {
+		return ml.Mounts[i].Id.Id < ml.Mounts[j].Id.Id
+	})
+	return ml, nil
+}
+
+func (s *service) GetMount(_ context.Context, rq *rpc.MountIdentifier) (*rpc.MountInfo, error) {
+	id := rq.GetId()
+	s.Lock()
+	defer s.Unlock()
+	m, ok := s.mounts[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "found no mount with id %d", id)
 	}
-	ss, err := net.ListenUnix("unix", sa)
-	if err != nil {
-		log.Fatalf("Listen to unix socket failed: %v\n", err)
+	return m.info(), nil
+}
+
+func rateLimits(rl *rpc.RateLimits) fs.RateLimits {
+	return fs.RateLimits{
+		Upload:         rl.GetUpload(),
+		Download:       rl.GetDownload(),
+		HandleUpload:   rl.GetHandleUpload(),
+		HandleDownload: rl.GetHandleDownload(),
 	}
-	gs := grpc.NewServer()
-	rpc.RegisterFuseFTPServer(gs, &service{
-		mounts: make(map[int32]*mount),
-		ctx:    context.Background()})
-	if err := gs.Serve(ss); err != nil {
-		log.Fatal(err.Error())
+}
+
+func timeouts(rq *rpc.MountRequest) fs.Timeouts {
+	return fs.Timeouts{
+		Dial:      rq.DialTimeout.AsDuration(),
+		Control:   rq.ControlTimeout.AsDuration(),
+		DataIdle:  rq.DataIdleTimeout.AsDuration(),
+		Operation: rq.OperationTimeout.AsDuration(),
+	}
+}
+
+func ftpCredentials(c *rpc.Credentials) fs.Credentials {
+	return fs.Credentials{User: c.GetUser(), Password: c.GetPassword()}
+}
+
+func poolLimits(pl *rpc.PoolLimits) fs.PoolLimits {
+	return fs.PoolLimits{MaxIdle: int(pl.GetMaxIdle())}
+}
+
+// mountOptions returns the MountOptions of the given FuseOptions. Root has access unless
+// the FuseOptions say otherwise, or give all users access.
+func mountOptions(fo *rpc.FuseOptions) fs.MountOptions {
+	if fo == nil {
+		return fs.DefaultMountOptions()
+	}
+	allowRoot := fs.DefaultMountOptions().AllowRoot && !fo.AllowOther
+	if fo.AllowRoot != nil {
+		allowRoot = *fo.AllowRoot
+	}
+	return fs.MountOptions{
+		AllowOther:      fo.AllowOther,
+		AllowRoot:       allowRoot,
+		UID:             fo.Uid,
+		GID:             fo.Gid,
+		Umask:           fo.Umask,
+		FSName:          fo.Fsname,
+		Subtype:         fo.Subtype,
+		MaxRead:         fo.MaxRead,
+		EntryTimeout:    durationPtr(fo.EntryTimeout),
+		AttrTimeout:     durationPtr(fo.AttrTimeout),
+		NegativeTimeout: durationPtr(fo.NegativeTimeout),
+		Extra:           fo.Extra,
+	}
+}
+
+func userCredentials(ucs map[uint32]*rpc.Credentials) map[uint32]fs.Credentials {
+	if len(ucs) == 0 {
+		return nil
+	}
+	m := make(map[uint32]fs.Credentials, len(ucs))
+	for uid, c := range ucs {
+		m[uid] = ftpCredentials(c)
+	}
+	return m
+}
+
+func identity(id *rpc.Identity) fs.Identity {
+	if id == nil {
+		return fs.Identity{}
+	}
+	return fs.Identity{
+		UID:           id.Uid,
+		GID:           id.Gid,
+		FileMode:      id.FileMode,
+		DirMode:       id.DirMode,
+		UseServerMode: id.UseServerMode,
+		Umask:         id.Umask,
+		Owners:        id.Owners,
+		Groups:        id.Groups,
+	}
+}
+
+// durationPtr returns a pointer to the duration, or nil when it is unset.
+func durationPtr(d *durationpb.Duration) *time.Duration {
+	if d == nil {
+		return nil
 	}
+	v := d.AsDuration()
+	return &v
+}
+
+func errorRules(rules []*rpc.ErrorRule) ([]fs.ErrorRule, error) {
+	ers := make([]fs.ErrorRule, len(rules))
+	for i, r := range rules {
+		errno, err := fs.ParseErrno(r.Errno)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		ers[i] = fs.ErrorRule{Code: int(r.Code), Message: r.Message, Errno: errno}
+	}
+	return ers, nil
+}
+
+func (s *service) SetRateLimits(_ context.Context, rq *rpc.SetRateLimitsRequest) (*emptypb.Empty, error) {
+	id := rq.Id.GetId()
+	s.Lock()
+	m, ok := s.mounts[id]
+	var fc fs.FTPClient
+	if ok {
+		fc = m.ftpClient
+		m.rateLimits = proto.Clone(rq.RateLimits).(*rpc.RateLimits)
+		s.saveState()
+	}
+	s.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "found no mount with id %d", id)
+	}
+	if fc != nil {
+		fc.SetRateLimits(rateLimits(rq.RateLimits))
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func newService(ctx context.Context) *service {
+	return &service{
+		mounts:         make(map[int32]*mount),
+		ctx:            ctx,
+		tracerProvider: &tracerProvider{},
+		health:         newHealthTracker(),
+		closing:        make(chan struct{}),
+		remountBackoff: exponentialBackoff(remountMinBackoff, remountMaxBackoff),
+	}
+}
+
+// exponentialBackoff returns a function that returns the delay before the given attempt, counted
+// from zero. The delay starts at min, and doubles with each attempt until it reaches max.
+func exponentialBackoff(min, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := min
+		for i := 0; i < attempt && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return d
+	}
+}
+
+// closeStreams ends all event streams, so that the server can stop without waiting for them.
+func (s *service) closeStreams() {
+	s.closingOnce.Do(func() { close(s.closing) })
+}
+
+// shutdown unmounts all mounts concurrently. Each unmount waits for ongoing writes to drain.
+// An error is returned when the ctx is done before all mounts have been unmounted.
+func (s *service) shutdown(ctx context.Context) error {
+	s.Lock()
+	s.stopping = true
+	ms := make([]*mount, 0, len(s.mounts))
+	for _, m := range s.mounts {
+		ms = append(ms, m)
+	}
+	s.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		wg.Add(len(ms))
+		for _, m := range ms {
+			go func(m *mount) {
+				defer wg.Done()
+				m.cancel()
+			}(m)
+		}
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:]))
 }
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// fakeClient is an fs.FTPClient that records the rate limits that it is given, and the names of
+// the setters that are called, and returns the given stats. Its other methods must not be called.
+type fakeClient struct {
+	fs.FTPClient
+	rateLimits fs.RateLimits
+	stats      fs.Stats
+	calls      []string
+
+	// mountedReadOnly makes SetReadOnly(false) fail, like it does for a read-only mount.
+	mountedReadOnly bool
+}
+
+func (c *fakeClient) SetTimeouts(time.Duration, fs.Timeouts) error {
+	c.calls = append(c.calls, "SetTimeouts")
+	return nil
+}
+
+func (c *fakeClient) SetDirectory(string) error {
+	c.calls = append(c.calls, "SetDirectory")
+	return nil
+}
+
+func (c *fakeClient) SetCredentials(fs.Credentials) error {
+	c.calls = append(c.calls, "SetCredentials")
+	return nil
+}
+
+func (c *fakeClient) SetPoolLimits(fs.PoolLimits) {
+	c.calls = append(c.calls, "SetPoolLimits")
+}
+
+func (c *fakeClient) SetReadOnly(ro bool) error {
+	c.calls = append(c.calls, "SetReadOnly")
+	if !ro && c.mountedReadOnly {
+		return errors.New("the file system is mounted read-only")
+	}
+	return nil
+}
+
+func (c *fakeClient) SetAttrCacheTTL(time.Duration) {
+	c.calls = append(c.calls, "SetAttrCacheTTL")
+}
+
+func (c *fakeClient) Stats() fs.Stats {
+	return c.stats
+}
+
+func (c *fakeClient) SetRateLimits(rl fs.RateLimits) {
+	c.calls = append(c.calls, "SetRateLimits")
+	c.rateLimits = rl
+}
+
+// addFakeMount adds a live mount with the given id to the service, using a fakeClient.
+func addFakeMount(s *service, id int32) *fakeClient {
+	fc := &fakeClient{}
+	s.mounts[id] = &mount{
+		id:         id,
+		mountPoint: "/mnt/ftp",
+		request:    &rpc.MountRequest{MountPoint: "/mnt/ftp", Directory: "exported"},
+		ftpServer:  netip.MustParseAddrPort("127.0.0.1:2121"),
+		ftpClient:  fc,
+		stop:       func() {},
+	}
+	return fc
+}
+
+// persistedRequest returns the request of the only mount in the state file.
+func persistedRequest(t *testing.T, file string) *rpc.MountRequest {
+	st, err := readState(file)
+	require.NoError(t, err)
+	require.Len(t, st.Mounts, 1)
+	rq := &rpc.MountRequest{}
+	require.NoError(t, protojson.Unmarshal(st.Mounts[0].Request, rq))
+	return rq
+}
+
+func TestUpdateMount(t *testing.T) {
+	ctx := context.Background()
+	s := newService(ctx)
+	s.stateFile = filepath.Join(t.TempDir(), "state.json")
+	fc := addFakeMount(s, 1)
+	dir := "exported/sub"
+	yes, no := true, false
+
+	// The settings are applied in the order that they are declared
+	info, err := s.UpdateMount(ctx, &rpc.UpdateMountRequest{
+		Id:           &rpc.MountIdentifier{Id: 1},
+		ReadTimeout:  durationpb.New(5 * time.Second),
+		Directory:    &dir,
+		Credentials:  &rpc.Credentials{User: "alice", Password: "secret"},
+		PoolLimits:   &rpc.PoolLimits{MaxIdle: 3},
+		RateLimits:   &rpc.RateLimits{Upload: 100},
+		ReadOnly:     &yes,
+		AttrCacheTtl: durationpb.New(time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SetTimeouts", "SetDirectory", "SetCredentials", "SetPoolLimits", "SetRateLimits", "SetReadOnly", "SetAttrCacheTTL"}, fc.calls)
+	assert.Equal(t, dir, info.Directory)
+	assert.Empty(t, info.Options.GetCredentials().GetPassword())
+
+	// The settings are recorded in the request of the mount and in the state file
+	for _, rq := range []*rpc.MountRequest{s.mounts[1].currentRequest(), persistedRequest(t, s.stateFile)} {
+		assert.Equal(t, 5*time.Second, rq.ReadTimeout.AsDuration())
+		assert.Equal(t, dir, rq.Directory)
+		assert.Equal(t, "alice", rq.GetCredentials().GetUser())
+		assert.Equal(t, int32(3), rq.GetPoolLimits().GetMaxIdle())
+		assert.Equal(t, int64(100), rq.GetRateLimits().GetUpload())
+		assert.True(t, rq.ReadOnly)
+		assert.Equal(t, time.Minute, rq.AttrCacheTtl.AsDuration())
+	}
+
+	// A setting that cannot be applied ends the update. The settings before it are kept.
+	fc.calls = nil
+	fc.mountedReadOnly = true
+	_, err = s.UpdateMount(ctx, &rpc.UpdateMountRequest{
+		Id:           &rpc.MountIdentifier{Id: 1},
+		PoolLimits:   &rpc.PoolLimits{MaxIdle: 5},
+		ReadOnly:     &no,
+		AttrCacheTtl: durationpb.New(time.Hour),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, []string{"SetPoolLimits", "SetReadOnly"}, fc.calls)
+	for _, rq := range []*rpc.MountRequest{s.mounts[1].currentRequest(), persistedRequest(t, s.stateFile)} {
+		assert.Equal(t, int32(5), rq.GetPoolLimits().GetMaxIdle())
+		assert.True(t, rq.ReadOnly)
+		assert.Equal(t, time.Minute, rq.AttrCacheTtl.AsDuration())
+	}
+
+	// The settings of a dead mount are only recorded
+	fc.calls = nil
+	s.mounts[1].stop = nil
+	_, err = s.UpdateMount(ctx, &rpc.UpdateMountRequest{Id: &rpc.MountIdentifier{Id: 1}, ReadOnly: &no})
+	require.NoError(t, err)
+	assert.Empty(t, fc.calls)
+	assert.False(t, persistedRequest(t, s.stateFile).ReadOnly)
+
+	_, err = s.UpdateMount(ctx, &rpc.UpdateMountRequest{Id: &rpc.MountIdentifier{Id: 2}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSetRateLimits(t *testing.T) {
+	ctx := context.Background()
+	s := newService(ctx)
+	s.stateFile = filepath.Join(t.TempDir(), "state.json")
+	fc := addFakeMount(s, 1)
+
+	_, err := s.SetRateLimits(ctx, &rpc.SetRateLimitsRequest{
+		Id:         &rpc.MountIdentifier{Id: 1},
+		RateLimits: &rpc.RateLimits{Download: 1000, HandleUpload: 10},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fs.RateLimits{Download: 1000, HandleUpload: 10}, fc.rateLimits)
+
+	// The limits are recorded, so that they are used when the mount is restored
+	rq := persistedRequest(t, s.stateFile)
+	assert.Equal(t, int64(1000), rq.GetRateLimits().GetDownload())
+	assert.Equal(t, int64(10), rq.GetRateLimits().GetHandleUpload())
+
+	_, err = s.SetRateLimits(ctx, &rpc.SetRateLimitsRequest{Id: &rpc.MountIdentifier{Id: 2}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestMountInfoRedacted(t *testing.T) {
+	ctx := context.Background()
+	s := newService(ctx)
+	addFakeMount(s, 1)
+	addFakeMount(s, 2)
+	s.mounts[1].request.Credentials = &rpc.Credentials{User: "alice", Password: "secret"}
+	s.mounts[1].request.UserCredentials = map[uint32]*rpc.Credentials{
+		1000: {User: "bob", Password: "secret"},
+		1001: nil,
+	}
+
+	ml, err := s.ListMounts(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, ml.Mounts, 2)
+	mi, err := s.GetMount(ctx, &rpc.MountIdentifier{Id: 1})
+	require.NoError(t, err)
+	for _, mi := range []*rpc.MountInfo{ml.Mounts[0], mi} {
+		opts := mi.Options
+		assert.Equal(t, "alice", opts.GetCredentials().GetUser())
+		assert.Empty(t, opts.GetCredentials().GetPassword())
+		assert.Equal(t, "bob", opts.UserCredentials[1000].GetUser())
+		assert.Empty(t, opts.UserCredentials[1000].GetPassword())
+		assert.NotContains(t, protojson.Format(mi), "secret")
+	}
+	assert.Nil(t, ml.Mounts[1].Options.GetCredentials())
+
+	// The passwords are kept, so that they are used when the mount is remounted
+	assert.Equal(t, "secret", s.mounts[1].request.Credentials.Password)
+	assert.Equal(t, "secret", s.mounts[1].request.UserCredentials[1000].Password)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := exponentialBackoff(remountMinBackoff, remountMaxBackoff)
+	var delays []time.Duration
+	for attempt := 0; attempt < 8; attempt++ {
+		delays = append(delays, backoff(attempt))
+	}
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second,
+		16 * time.Second, 32 * time.Second, time.Minute, time.Minute,
+	}, delays)
+	assert.Equal(t, time.Minute, backoff(1000))
+}
+
+// closedFTPServer returns the address of a port that nothing listens to.
+func closedFTPServer(t *testing.T) *rpc.AddressAndPort {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := l.Addr().(*net.TCPAddr).Port
+	require.NoError(t, l.Close())
+	return &rpc.AddressAndPort{Ip: []byte{127, 0, 0, 1}, Port: int32(port)}
+}
+
+func TestMountDied(t *testing.T) {
+	s := newService(context.Background())
+	attempts := make(chan int, 100)
+	s.remountBackoff = func(attempt int) time.Duration {
+		attempts <- attempt
+		return time.Millisecond
+	}
+	newDyingMount := func(id int32, autoRemount bool) (*mount, *bool) {
+		ap := closedFTPServer(t)
+		m, _, _ := s.newMount(id, &rpc.MountRequest{MountPoint: t.TempDir(), FtpServer: ap, AutoRemount: autoRemount}, netip.MustParseAddrPort(fmt.Sprintf("127.0.0.1:%d", ap.Port)))
+		stopped := false
+		m.stop = func() { stopped = true }
+		s.Lock()
+		s.mounts[id] = m
+		s.Unlock()
+		s.health.addMount(id)
+		return m, &stopped
+	}
+	mountState := func(m *mount) (rpc.MountState, string) {
+		s.Lock()
+		defer s.Unlock()
+		return m.state, m.err
+	}
+
+	// A mount that isn't remounted is dead
+	m, stopped := newDyingMount(1, false)
+	s.mountDied(m, m.stop, "unmounted by fusermount")
+	assert.True(t, *stopped)
+	state, msg := mountState(m)
+	assert.Equal(t, rpc.MountState_MOUNT_STATE_DEAD, state)
+	assert.Equal(t, "unmounted by fusermount", msg)
+	assert.Empty(t, attempts)
+
+	// The remount attempts continue, with increasing delays, until the mount is unmounted
+	m, stopped = newDyingMount(2, true)
+	done := make(chan struct{})
+	go func() {
+		s.mountDied(m, m.stop, "unmounted by fusermount")
+		close(done)
+	}()
+	for i := 0; i < 3; i++ {
+		select {
+		case attempt := <-attempts:
+			assert.Equal(t, i, attempt)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no remount attempt")
+		}
+	}
+	assert.True(t, *stopped)
+	state, msg = mountState(m)
+	assert.Equal(t, rpc.MountState_MOUNT_STATE_REMOUNTING, state)
+	assert.Contains(t, msg, "connection refused")
+
+	m.cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the remount attempts did not end")
+	}
+}
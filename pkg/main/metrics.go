@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+)
+
+var (
+	mountLabels = []string{"mount_id", "mount_point"}
+
+	opsDesc = prometheus.NewDesc("fuseftp_fuse_operations_total",
+		"Number of FUSE operations.",
+		append(mountLabels, "operation"), nil)
+	opErrorsDesc = prometheus.NewDesc("fuseftp_fuse_operation_errors_total",
+		"Number of FUSE operations that returned an error.",
+		append(mountLabels, "operation"), nil)
+	opDurationDesc = prometheus.NewDesc("fuseftp_fuse_operation_duration_seconds",
+		"Latency of FUSE operations.",
+		append(mountLabels, "operation"), nil)
+	errnoDesc = prometheus.NewDesc("fuseftp_errors_total",
+		"Number of FUSE operations that returned an error, by errno.",
+		append(mountLabels, "errno"), nil)
+	replyCodeDesc = prometheus.NewDesc("fuseftp_ftp_reply_errors_total",
+		"Number of FTP commands that failed, by FTP reply code.",
+		append(mountLabels, "code"), nil)
+	poolConnsDesc = prometheus.NewDesc("fuseftp_pool_connections",
+		"Number of connections in the connection pool.",
+		append(mountLabels, "state"), nil)
+	poolDialsDesc = prometheus.NewDesc("fuseftp_pool_dials_total",
+		"Number of attempts to dial and log in to the FTP server.",
+		mountLabels, nil)
+	poolDialFailuresDesc = prometheus.NewDesc("fuseftp_pool_dial_failures_total",
+		"Number of failed attempts to dial and log in to the FTP server.",
+		mountLabels, nil)
+	transferBytesDesc = prometheus.NewDesc("fuseftp_transfer_bytes_total",
+		"Number of bytes transferred to or from files on the FTP server.",
+		append(mountLabels, "direction"), nil)
+	openHandlesDesc = prometheus.NewDesc("fuseftp_open_handles",
+		"Number of open file handles.",
+		mountLabels, nil)
+)
+
+// metricsCollector is a prometheus.Collector that collects the statistics of all mounts.
+type metricsCollector struct {
+	s *service
+}
+
+func (c metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		opsDesc, opErrorsDesc, opDurationDesc, errnoDesc, replyCodeDesc,
+		poolConnsDesc, poolDialsDesc, poolDialFailuresDesc, transferBytesDesc, openHandlesDesc,
+	} {
+		ch <- d
+	}
+}
+
+func (c metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	type mountStats struct {
+		labels []string
+		stats  fs.Stats
+	}
+	c.s.Lock()
+	ms := make([]mountStats, 0, len(c.s.mounts))
+	clients := make([]fs.FTPClient, 0, len(c.s.mounts))
+	for id, m := range c.s.mounts {
+		if m.ftpClient == nil {
+			continue
+		}
+		ms = append(ms, mountStats{labels: []string{strconv.Itoa(int(id)), m.mountPoint}})
+		clients = append(clients, m.ftpClient)
+	}
+	c.s.Unlock()
+
+	for i := range ms {
+		ms[i].stats = clients[i].Stats()
+	}
+	for _, m := range ms {
+		lv := func(extra ...string) []string {
+			return append(append(make([]string, 0, len(m.labels)+len(extra)), m.labels...), extra...)
+		}
+		st := m.stats
+		for op, ost := range st.Operations {
+			ch <- prometheus.MustNewConstMetric(opsDesc, prometheus.CounterValue, float64(ost.Count), lv(op)...)
+			ch <- prometheus.MustNewConstMetric(opErrorsDesc, prometheus.CounterValue, float64(ost.Errors), lv(op)...)
+			buckets := make(map[float64]uint64, len(fs.LatencyBuckets))
+			var cumulative uint64
+			for i, ub := range fs.LatencyBuckets {
+				cumulative += ost.Buckets[i]
+				buckets[ub.Seconds()] = cumulative
+			}
+			ch <- prometheus.MustNewConstHistogram(opDurationDesc, ost.Count, ost.TotalLatency.Seconds(), buckets, lv(op)...)
+		}
+		for errno, n := range st.Errnos {
+			ch <- prometheus.MustNewConstMetric(errnoDesc, prometheus.CounterValue, float64(n), lv(fs.ErrnoName(errno))...)
+		}
+		for code, n := range st.ReplyCodes {
+			ch <- prometheus.MustNewConstMetric(replyCodeDesc, prometheus.CounterValue, float64(n), lv(strconv.Itoa(code))...)
+		}
+		ch <- prometheus.MustNewConstMetric(poolConnsDesc, prometheus.GaugeValue, float64(st.Pool.Idle), lv("idle")...)
+		ch <- prometheus.MustNewConstMetric(poolConnsDesc, prometheus.GaugeValue, float64(st.Pool.Busy), lv("busy")...)
+		ch <- prometheus.MustNewConstMetric(poolDialsDesc, prometheus.CounterValue, float64(st.Pool.Dials), lv()...)
+		ch <- prometheus.MustNewConstMetric(poolDialFailuresDesc, prometheus.CounterValue, float64(st.Pool.DialFailures), lv()...)
+		ch <- prometheus.MustNewConstMetric(transferBytesDesc, prometheus.CounterValue, float64(st.BytesRead), lv("read")...)
+		ch <- prometheus.MustNewConstMetric(transferBytesDesc, prometheus.CounterValue, float64(st.BytesWritten), lv("write")...)
+		ch <- prometheus.MustNewConstMetric(openHandlesDesc, prometheus.GaugeValue, float64(st.OpenHandles), lv()...)
+	}
+}
+
+// serveMetrics serves the Prometheus metrics of all mounts on http://<addr>/metrics until
+// the context is cancelled.
+func serveMetrics(ctx context.Context, addr string, s *service) error {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		metricsCollector{s: s},
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("metrics server failed: %v", err)
+		}
+	}()
+	logrus.Infof("serving metrics on http://%s/metrics", l.Addr())
+	return nil
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+)
+
+func TestMetricsCollector(t *testing.T) {
+	s := newService(context.Background())
+	fc := addFakeMount(s, 1)
+	fc.stats = fs.Stats{
+		Operations: map[string]fs.OpStats{
+			"Read": {
+				Count:        7,
+				Errors:       1,
+				TotalLatency: 20 * time.Second,
+				Buckets:      []uint64{1, 0, 2, 0, 0, 0, 0, 0, 0, 0, 1, 3},
+			},
+		},
+		Errnos:       map[int]uint64{5: 1},
+		Pool:         fs.PoolStats{Idle: 2, Busy: 1, Dials: 4, DialFailures: 1},
+		BytesRead:    1024,
+		BytesWritten: 512,
+		OpenHandles:  3,
+	}
+
+	// The buckets of the histogram are cumulative, and calls that exceed the last bucket are
+	// only counted by the +Inf bucket.
+	const expected = `
+# HELP fuseftp_fuse_operation_duration_seconds Latency of FUSE operations.
+# TYPE fuseftp_fuse_operation_duration_seconds histogram
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.0001"} 1
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.0005"} 1
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.001"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.005"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.01"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.05"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.1"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="0.5"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="1"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="5"} 3
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="10"} 4
+fuseftp_fuse_operation_duration_seconds_bucket{mount_id="1",mount_point="/mnt/ftp",operation="Read",le="+Inf"} 7
+fuseftp_fuse_operation_duration_seconds_sum{mount_id="1",mount_point="/mnt/ftp",operation="Read"} 20
+fuseftp_fuse_operation_duration_seconds_count{mount_id="1",mount_point="/mnt/ftp",operation="Read"} 7
+# HELP fuseftp_fuse_operation_errors_total Number of FUSE operations that returned an error.
+# TYPE fuseftp_fuse_operation_errors_total counter
+fuseftp_fuse_operation_errors_total{mount_id="1",mount_point="/mnt/ftp",operation="Read"} 1
+# HELP fuseftp_pool_connections Number of connections in the connection pool.
+# TYPE fuseftp_pool_connections gauge
+fuseftp_pool_connections{mount_id="1",mount_point="/mnt/ftp",state="busy"} 1
+fuseftp_pool_connections{mount_id="1",mount_point="/mnt/ftp",state="idle"} 2
+# HELP fuseftp_transfer_bytes_total Number of bytes transferred to or from files on the FTP server.
+# TYPE fuseftp_transfer_bytes_total counter
+fuseftp_transfer_bytes_total{direction="read",mount_id="1",mount_point="/mnt/ftp"} 1024
+fuseftp_transfer_bytes_total{direction="write",mount_id="1",mount_point="/mnt/ftp"} 512
+`
+	assert.NoError(t, testutil.CollectAndCompare(metricsCollector{s: s}, strings.NewReader(expected),
+		"fuseftp_fuse_operation_duration_seconds",
+		"fuseftp_fuse_operation_errors_total",
+		"fuseftp_pool_connections",
+		"fuseftp_transfer_bytes_total",
+	))
+
+	// A mount without a client, e.g. one that failed to restore, has no metrics
+	s.mounts[1].ftpClient = nil
+	assert.Equal(t, 0, testutil.CollectAndCount(metricsCollector{s: s}))
+}
@@ -0,0 +1,16 @@
+//go:build !linux && !darwin
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+)
+
+const peerCredSupported = false
+
+// peerUID is not supported on this platform.
+func peerUID(*net.UnixConn) (uint32, error) {
+	return 0, fmt.Errorf("peer credentials are not supported on %s", runtime.GOOS)
+}
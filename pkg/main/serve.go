@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// runServe implements the "serve" command, which runs the daemon.
+func runServe(args []string) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	socket := flags.String("socket", defaultSocket(), "path of the unix socket that the API is served on")
+	configFile := flags.String("config", "", "read settings and the mounts to create at startup from this YAML or TOML file")
+	logLevel := flags.String("log-level", "info", "the logrus log level")
+	logFormat := flags.String("log-format", "text", `the log format, "text" or "json"`)
+	metricsAddr := flags.String("metrics-addr", "", "serve Prometheus metrics on this address, e.g. :9090")
+	stateFile := flags.String("state-file", "", "record the mounts in this file, and restore them when the daemon starts")
+	shutdownTimeout := flags.Duration("shutdown-timeout", defaultShutdownTimeout, "maximum time to wait for API calls to complete and for all mounts to be unmounted when terminated")
+	tracing := &rpc.TracingConfig{}
+	flags.StringVar(&tracing.OtlpEndpoint, "otlp-endpoint", "", "export OpenTelemetry spans to this OTLP/gRPC endpoint (host:port)")
+	flags.BoolVar(&tracing.OtlpInsecure, "otlp-insecure", false, "use a plaintext connection to the OTLP endpoint")
+	traceFile := flags.String("trace-file", "", "append OpenTelemetry spans as JSON to this file")
+	allowedUIDs := flags.String("allowed-uids", "", "comma separated uids that may create or change mounts using the unix socket. The uid of the daemon is always allowed, and is the only one that can use the socket when empty")
+	tcpAddr := flags.String("tcp-addr", "", "also serve the API on this TCP address, e.g. :8443. Requires -tls-cert and -tls-key")
+	tlsCert := flags.String("tls-cert", "", "TLS certificate file for the TCP listener")
+	tlsKey := flags.String("tls-key", "", "TLS key file for the TCP listener")
+	tlsClientCA := flags.String("tls-client-ca", "", "require TCP clients to present a certificate signed by a CA in this file")
+	tokenFile := flags.String("token-file", "", "require TCP clients to send the bearer token found in this file")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s serve [flags] [<path to unix socket>]\n\nRun the daemon.\n\n", progName)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() > 1 {
+		flags.Usage()
+		return 2
+	}
+
+	var cfg config
+	if *configFile != "" {
+		var err error
+		if cfg, err = readConfig(*configFile); err != nil {
+			log.Printf("Failed to read config: %v\n", err)
+			return 1
+		}
+		if err = applyConfig(flags, &cfg); err != nil {
+			log.Printf("Invalid config: %v\n", err)
+			return 1
+		}
+	}
+	if flags.NArg() == 1 {
+		*socket = flags.Arg(0)
+	}
+	if err := configureLogging(*logLevel, *logFormat); err != nil {
+		log.Println(err)
+		return 2
+	}
+	requests := make([]*rpc.MountRequest, len(cfg.Mounts))
+	for i := range cfg.Mounts {
+		var err error
+		if requests[i], err = cfg.Mounts[i].request(); err != nil {
+			log.Printf("Invalid mount %d in config: %v\n", i+1, err)
+			return 1
+		}
+	}
+
+	var unixOpts []grpc.ServerOption
+	if *allowedUIDs != "" {
+		if !peerCredSupported {
+			log.Printf("-allowed-uids is not supported on %s\n", runtime.GOOS)
+			return 2
+		}
+		uids, err := parseUIDs(*allowedUIDs)
+		if err != nil {
+			log.Printf("Invalid -allowed-uids: %v\n", err)
+			return 2
+		}
+		unixOpts = interceptors(uidAuth(uids))
+	}
+
+	// serving is set once the gRPC servers have taken over the listeners. Until then, the
+	// deferred functions below release what has been set up when the daemon fails to start.
+	serving := false
+
+	var tcpListener net.Listener
+	var tcpOpts []grpc.ServerOption
+	if *tcpAddr != "" {
+		creds, err := serverTLS(*tlsCert, *tlsKey, *tlsClientCA)
+		if err != nil {
+			log.Printf("Failed to configure TLS: %v\n", err)
+			return 1
+		}
+		tcpOpts = append(tcpOpts, grpc.Creds(creds))
+		if *tokenFile != "" {
+			token, err := readToken(*tokenFile)
+			if err != nil {
+				log.Printf("Failed to read token: %v\n", err)
+				return 1
+			}
+			tcpOpts = append(tcpOpts, interceptors(tokenAuth(token))...)
+		} else if *tlsClientCA == "" {
+			log.Println("A TCP listener requires -tls-client-ca or -token-file")
+			return 2
+		}
+		if tcpListener, err = net.Listen("tcp", *tcpAddr); err != nil {
+			log.Printf("Listen to TCP address failed: %v\n", err)
+			return 1
+		}
+		defer func() {
+			if !serving {
+				_ = tcpListener.Close()
+			}
+		}()
+	}
+
+	// Yes, this works on Windows too
+	sa, err := net.ResolveUnixAddr("unix", *socket)
+	if err != nil {
+		log.Printf("Failed to resolve unix socket address: %v\n", err)
+		return 1
+	}
+	ss, err := net.ListenUnix("unix", sa)
+	if err != nil {
+		log.Printf("Listen to unix socket failed: %v\n", err)
+		return 1
+	}
+	defer func() {
+		if !serving {
+			_ = ss.Close()
+		}
+	}()
+	if runtime.GOOS != "windows" {
+		// Without -allowed-uids, nothing restricts the calls, so only the user of the daemon may
+		// connect. Otherwise, all users may connect, and uidAuth decides what they can call.
+		mode := os.FileMode(0o600)
+		if *allowedUIDs != "" {
+			mode = 0o666
+		}
+		if err := os.Chmod(*socket, mode); err != nil {
+			log.Printf("Failed to set the mode of the unix socket: %v\n", err)
+			return 1
+		}
+	}
+	// The signal context is only used to detect the signals. The service uses its own context, so that
+	// the mounts are stopped in an orderly fashion when a signal arrives.
+	sigCtx, stopSignals := signalContext()
+	defer stopSignals()
+	// Cancelling the ctx stops the metrics server.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newService(ctx)
+	s.stateFile = *stateFile
+	defer func() {
+		if !serving {
+			// Unmount the mounts that were restored. They remain in the state file.
+			sdCtx, sdCancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+			defer sdCancel()
+			_ = s.shutdown(sdCtx)
+			_ = s.tracerProvider.close(sdCtx)
+		}
+	}()
+	s.tracerProvider.file = *traceFile
+	if err := s.tracerProvider.configure(ctx, tracing); err != nil {
+		log.Printf("Failed to configure tracing: %v\n", err)
+		return 1
+	}
+	if *metricsAddr != "" {
+		if err := serveMetrics(ctx, *metricsAddr, s); err != nil {
+			log.Printf("Failed to serve metrics: %v\n", err)
+			return 1
+		}
+	}
+	if s.stateFile != "" {
+		configured := make(map[string]bool, len(requests))
+		for _, rq := range requests {
+			configured[rq.MountPoint] = true
+		}
+		if err := s.restoreState(configured); err != nil {
+			log.Printf("Failed to restore mounts: %v\n", err)
+			return 1
+		}
+	}
+	for _, rq := range requests {
+		if id, err := s.mount(rq, true); err != nil {
+			logrus.Errorf("failed to mount %s: %v", rq.MountPoint, err)
+		} else {
+			logrus.Infof("mounted %s with id %d", rq.MountPoint, id.Id)
+		}
+	}
+
+	var servers []*grpc.Server
+	errCh := make(chan error, 2)
+	serve := func(l net.Listener, opts []grpc.ServerOption) {
+		gs := grpc.NewServer(opts...)
+		rpc.RegisterFuseFTPServer(gs, s)
+		healthpb.RegisterHealthServer(gs, s.health)
+		servers = append(servers, gs)
+		go func() { errCh <- gs.Serve(l) }()
+	}
+	serve(peerCredListener{UnixListener: ss}, unixOpts)
+	if tcpListener != nil {
+		serve(tcpListener, tcpOpts)
+	}
+	serving = true
+
+	exitCode := 0
+	select {
+	case err := <-errCh:
+		logrus.Errorf("serve failed: %v", err)
+		exitCode = 1
+	case <-sigCtx.Done():
+		logrus.Info("shutting down")
+	}
+	// Restore the default behavior so that a second signal terminates the process immediately.
+	stopSignals()
+
+	sdCtx, sdCancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer sdCancel()
+	s.closeStreams()
+	stopServers(sdCtx, servers)
+	if err := s.shutdown(sdCtx); err != nil {
+		logrus.Errorf("all mounts were not unmounted within %s", *shutdownTimeout)
+		exitCode = 1
+	}
+	if err := s.tracerProvider.close(sdCtx); err != nil {
+		logrus.Errorf("failed to flush traces: %v", err)
+	}
+	return exitCode
+}
+
+// applyConfig sets the flags that weren't given explicitly to the settings in the config file, so
+// that explicitly given flags take precedence over the config file.
+func applyConfig(flags *flag.FlagSet, cfg *config) error {
+	set := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	settings := []struct{ name, value string }{
+		{"socket", cfg.Socket},
+		{"log-level", cfg.LogLevel},
+		{"log-format", cfg.LogFormat},
+		{"metrics-addr", cfg.MetricsAddr},
+		{"state-file", cfg.StateFile},
+	}
+	if cfg.ShutdownTimeout > 0 {
+		settings = append(settings, struct{ name, value string }{"shutdown-timeout", cfg.ShutdownTimeout.String()})
+	}
+	for _, st := range settings {
+		if !set[st.name] && st.value != "" {
+			if err := flags.Set(st.name, st.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stopServers stops the given servers gracefully, so that ongoing calls are allowed to complete.
+// Calls that are still running when the ctx is done are cancelled.
+func stopServers(ctx context.Context, servers []*grpc.Server) {
+	var wg sync.WaitGroup
+	wg.Add(len(servers))
+	for _, gs := range servers {
+		go func(gs *grpc.Server) {
+			defer wg.Done()
+			done := make(chan struct{})
+			go func() {
+				gs.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-ctx.Done():
+				gs.Stop()
+			}
+		}(gs)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// slowServer is a FuseFTP server whose Version calls wait for release, or for the call to be
+// cancelled.
+type slowServer struct {
+	rpc.UnimplementedFuseFTPServer
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *slowServer) Version(ctx context.Context, _ *emptypb.Empty) (*rpc.VersionInfo, error) {
+	close(s.started)
+	select {
+	case <-s.release:
+		return &rpc.VersionInfo{Semver: version}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestStopServers(t *testing.T) {
+	// startCall starts a server and a slow call to it, and returns the server, the release
+	// channel of the call, and a channel that receives the result of the call.
+	startCall := func(t *testing.T) (*grpc.Server, chan struct{}, chan error) {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		ss := &slowServer{started: make(chan struct{}), release: make(chan struct{})}
+		gs := grpc.NewServer()
+		rpc.RegisterFuseFTPServer(gs, ss)
+		go func() { _ = gs.Serve(l) }()
+		t.Cleanup(gs.Stop)
+
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := rpc.NewFuseFTPClient(conn).Version(context.Background(), &emptypb.Empty{})
+			errCh <- err
+		}()
+		select {
+		case <-ss.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the call did not start")
+		}
+		return gs, ss.release, errCh
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		gs, release, errCh := startCall(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		stopped := make(chan struct{})
+		go func() {
+			stopServers(ctx, []*grpc.Server{gs})
+			close(stopped)
+		}()
+
+		// The server waits for the call
+		select {
+		case <-stopped:
+			t.Fatal("the server stopped before the call completed")
+		case <-time.After(100 * time.Millisecond):
+		}
+		close(release)
+		assert.NoError(t, <-errCh)
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the server did not stop")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		gs, _, errCh := startCall(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		stopServers(ctx, []*grpc.Server{gs})
+		assert.Less(t, time.Since(start), 5*time.Second)
+		select {
+		case err := <-errCh:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the call was not cancelled")
+		}
+	})
+}
+
+func TestShutdown(t *testing.T) {
+	s := newService(context.Background())
+	addFakeMount(s, 1)
+	addFakeMount(s, 2)
+	release := make(chan struct{})
+	unmounted := make(chan int32, 2)
+	for id, m := range s.mounts {
+		id := id
+		m.cancel = func() {
+			<-release
+			unmounted <- id
+		}
+	}
+
+	// The mounts are unmounted concurrently, and shutdown gives up when the ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.shutdown(ctx), context.DeadlineExceeded)
+	assert.True(t, s.stopping)
+	close(release)
+	assert.ElementsMatch(t, []int32{1, 2}, []int32{<-unmounted, <-unmounted})
+
+	// A shutdown that completes in time
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, s.shutdown(ctx))
+	assert.Len(t, unmounted, 2)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// state is the contents of the state file.
+type state struct {
+	Mounts []persistedMount `json:"mounts"`
+}
+
+// persistedMount is a mount recorded in the state file. The request is encoded using protojson.
+type persistedMount struct {
+	ID      int32           `json:"id"`
+	Request json.RawMessage `json:"request"`
+}
+
+// saveState writes all mounts, except those declared in the config file, to the state file.
+// Errors are logged. The service must be locked
+// when this method is called.
+func (s *service) saveState() {
+	if s.stateFile == "" || s.stopping {
+		return
+	}
+	st := state{Mounts: make([]persistedMount, 0, len(s.mounts))}
+	for id, m := range s.mounts {
+		if m.fromConfig {
+			continue
+		}
+		data, err := protojson.Marshal(m.currentRequest())
+		if err != nil {
+			logrus.Errorf("failed to encode mount %d: %v", id, err)
+			continue
+		}
+		st.Mounts = append(st.Mounts, persistedMount{ID: id, Request: data})
+	}
+	sort.Slice(st.Mounts, func(i, j int) bool { return st.Mounts[i].ID < st.Mounts[j].ID })
+	if err := writeState(s.stateFile, &st); err != nil {
+		logrus.Errorf("failed to write state file: %v", err)
+	}
+}
+
+// writeState replaces the state file atomically. The file may contain FTP and proxy credentials, so it
+// is only readable by its owner.
+func writeState(file string, st *state) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
+// readState reads the state file. A file that doesn't exist is an empty state.
+func readState(file string) (*state, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return &state{}, nil
+		}
+		return nil, err
+	}
+	var st state
+	if err = json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return &st, nil
+}
+
+// restoreState re-creates the mounts that are recorded in the state file, using the same ids.
+// A mount that cannot be restored is kept in the MOUNT_STATE_FAILED state, so that it is
+// reported by the API, and retried if it has auto_remount set. Mounts of the given config mount
+// points are skipped, so that the config file decides how they are mounted.
+func (s *service) restoreState(configured map[string]bool) error {
+	st, err := readState(s.stateFile)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	for _, pm := range st.Mounts {
+		if pm.ID >= s.nextID {
+			s.nextID = pm.ID + 1
+		}
+		rq := &rpc.MountRequest{}
+		if err := protojson.Unmarshal(pm.Request, rq); err != nil {
+			logrus.Errorf("failed to decode mount %d in state file: %v", pm.ID, err)
+			continue
+		}
+		if configured[rq.MountPoint] {
+			logrus.Infof("mount %d of %s is replaced by the config file", pm.ID, rq.MountPoint)
+			continue
+		}
+		ap, err := addrPort(rq.GetFtpServer())
+		m, ctx, cancel := s.newMount(pm.ID, rq, ap)
+		s.mounts[pm.ID] = m
+		s.health.addMount(pm.ID)
+		if err == nil {
+			m.ftpClient, m.stop, err = s.mountFS(ctx, m, rq, ap)
+		}
+		if err == nil {
+			m.log.Info("restored mount")
+			continue
+		}
+		cancel()
+		m.log.Errorf("failed to restore mount: %v", err)
+		m.err = "restore failed: " + err.Error()
+		m.state = rpc.MountState_MOUNT_STATE_FAILED
+		if rq.AutoRemount {
+			m.state = rpc.MountState_MOUNT_STATE_REMOUNTING
+			go s.remount(m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// persistedIDs returns the ids of the mounts in the state file.
+func persistedIDs(t *testing.T, file string) []int32 {
+	st, err := readState(file)
+	require.NoError(t, err)
+	ids := make([]int32, 0, len(st.Mounts))
+	for _, pm := range st.Mounts {
+		ids = append(ids, pm.ID)
+	}
+	return ids
+}
+
+func TestRestoreState(t *testing.T) {
+	s := newService(context.Background())
+	s.stateFile = filepath.Join(t.TempDir(), "state.json")
+	attempts := make(chan int, 100)
+	s.remountBackoff = func(attempt int) time.Duration {
+		attempts <- attempt
+		return time.Hour
+	}
+
+	// The mounts have no FTP server, so they cannot be mounted and are restored as failed, or
+	// as remounting when they are remounted automatically.
+	persisted := func(id int32, mountPoint string, autoRemount bool) persistedMount {
+		data, err := protojson.Marshal(&rpc.MountRequest{MountPoint: mountPoint, Directory: "exported", AutoRemount: autoRemount})
+		require.NoError(t, err)
+		return persistedMount{ID: id, Request: data}
+	}
+	require.NoError(t, writeState(s.stateFile, &state{Mounts: []persistedMount{
+		persisted(3, "/mnt/api", false),
+		persisted(4, "/mnt/auto", true),
+		persisted(5, "/mnt/config", false),
+		{ID: 6, Request: json.RawMessage(`{"mountPoint": 1}`)},
+	}}))
+
+	require.NoError(t, s.restoreState(map[string]bool{"/mnt/config": true}))
+	s.Lock()
+	require.Len(t, s.mounts, 2)
+	m := s.mounts[3]
+	require.NotNil(t, m)
+	assert.Equal(t, "/mnt/api", m.mountPoint)
+	assert.Equal(t, rpc.MountState_MOUNT_STATE_FAILED, m.state)
+	assert.Contains(t, m.err, "restore failed")
+	m = s.mounts[4]
+	require.NotNil(t, m)
+	assert.Equal(t, rpc.MountState_MOUNT_STATE_REMOUNTING, m.state)
+	s.Unlock()
+	select {
+	case attempt := <-attempts:
+		assert.Zero(t, attempt)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no remount attempt")
+	}
+
+	// The ids of skipped and undecodable mounts are not reused
+	assert.Equal(t, int32(7), s.nextID)
+}
+
+func TestSaveState(t *testing.T) {
+	s := newService(context.Background())
+	s.stateFile = filepath.Join(t.TempDir(), "state.json")
+	addFakeMount(s, 1)
+	addFakeMount(s, 2)
+	addFakeMount(s, 4)
+
+	s.Lock()
+	s.mounts[2].fromConfig = true
+	s.saveState()
+	s.Unlock()
+	assert.Equal(t, []int32{1, 4}, persistedIDs(t, s.stateFile))
+
+	// Mounts that are removed while the daemon stops are kept in the state file
+	s.Lock()
+	s.stopping = true
+	delete(s.mounts, 1)
+	s.saveState()
+	s.Unlock()
+	assert.Equal(t, []int32{1, 4}, persistedIDs(t, s.stateFile))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func (s *service) GetStats(_ context.Context, rq *rpc.MountIdentifier) (*rpc.MountStats, error) {
+	id := rq.GetId()
+	s.Lock()
+	m, ok := s.mounts[id]
+	var fc fs.FTPClient
+	if ok {
+		fc = m.ftpClient
+	}
+	s.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "found no mount with id %d", id)
+	}
+	if fc == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "mount %d has not been mounted", id)
+	}
+	return mountStats(id, fc.Stats()), nil
+}
+
+func mountStats(id int32, st fs.Stats) *rpc.MountStats {
+	ms := &rpc.MountStats{
+		Id:         &rpc.MountIdentifier{Id: id},
+		Operations: make(map[string]*rpc.OperationStats, len(st.Operations)),
+		Pool: &rpc.PoolStats{
+			Idle:         int32(st.Pool.Idle),
+			Busy:         int32(st.Pool.Busy),
+			Dials:        st.Pool.Dials,
+			DialFailures: st.Pool.DialFailures,
+		},
+		BytesRead:         st.BytesRead,
+		BytesWritten:      st.BytesWritten,
+		OpenHandles:       int32(st.OpenHandles),
+		ErrorsByErrno:     make(map[string]uint64, len(st.Errnos)),
+		ErrorsByReplyCode: make(map[int32]uint64, len(st.ReplyCodes)),
+	}
+	for op, ost := range st.Operations {
+		lbs := make([]*rpc.LatencyBucket, len(ost.Buckets))
+		for i, c := range ost.Buckets {
+			lb := &rpc.LatencyBucket{Count: c}
+			if i < len(fs.LatencyBuckets) {
+				lb.UpperBound = durationpb.New(fs.LatencyBuckets[i])
+			}
+			lbs[i] = lb
+		}
+		ms.Operations[op] = &rpc.OperationStats{
+			Count:        ost.Count,
+			Errors:       ost.Errors,
+			TotalLatency: durationpb.New(ost.TotalLatency),
+			Latency:      lbs,
+		}
+	}
+	for errno, c := range st.Errnos {
+		ms.ErrorsByErrno[fs.ErrnoName(errno)] = c
+	}
+	for code, c := range st.ReplyCodes {
+		ms.ErrorsByReplyCode[int32(code)] = c
+	}
+	return ms
+}
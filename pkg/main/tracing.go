@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// tracerProvider is a trace.TracerProvider whose configuration can be replaced at runtime.
+// The tracers that it returns always use the current configuration.
+type tracerProvider struct {
+	sync.RWMutex
+
+	// file is the file that spans are appended to in addition to the configured exporters. It
+	// is given to serve using -trace-file, and cannot be changed using the API, because the
+	// daemon often runs as root.
+	file string
+
+	sdk    *sdktrace.TracerProvider
+	closer io.Closer
+}
+
+type tracer struct {
+	tp   *tracerProvider
+	name string
+	opts []trace.TracerOption
+}
+
+func (tp *tracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &tracer{tp: tp, name: name, opts: opts}
+}
+
+func (t *tracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	t.tp.RLock()
+	var tr trace.Tracer
+	if t.tp.sdk != nil {
+		tr = t.tp.sdk.Tracer(t.name, t.opts...)
+	} else {
+		tr = trace.NewNoopTracerProvider().Tracer(t.name)
+	}
+	t.tp.RUnlock()
+	return tr.Start(ctx, spanName, opts...)
+}
+
+// configure replaces the current configuration. Spans of the previous configuration are
+// flushed before this method returns.
+func (tp *tracerProvider) configure(ctx context.Context, cfg *rpc.TracingConfig) error {
+	sdk, closer, err := tp.newSDK(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	return tp.install(ctx, sdk, closer)
+}
+
+// newSDK returns a TracerProvider that exports spans according to the given configuration and
+// to the trace file, together with the trace file, or nil when no spans are exported.
+func (tp *tracerProvider) newSDK(ctx context.Context, cfg *rpc.TracingConfig) (*sdktrace.TracerProvider, io.Closer, error) {
+	if cfg.GetOtlpEndpoint() == "" && tp.file == "" {
+		return nil, nil, nil
+	}
+	var opts []sdktrace.TracerProviderOption
+	var otlpExp sdktrace.SpanExporter
+	if ep := cfg.GetOtlpEndpoint(); ep != "" {
+		eOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(ep)}
+		if cfg.OtlpInsecure {
+			eOpts = append(eOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, eOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create OTLP exporter: %w", err)
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+		otlpExp = exp
+	}
+	var closer io.Closer
+	if tp.file != "" {
+		f, err := os.OpenFile(tp.file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err == nil {
+			var exp *stdouttrace.Exporter
+			if exp, err = stdouttrace.New(stdouttrace.WithWriter(f)); err == nil {
+				opts = append(opts, sdktrace.WithSyncer(exp))
+				closer = f
+			} else {
+				_ = f.Close()
+				err = fmt.Errorf("unable to create file exporter: %w", err)
+			}
+		} else {
+			err = fmt.Errorf("unable to open trace file: %w", err)
+		}
+		if err != nil {
+			if otlpExp != nil {
+				_ = otlpExp.Shutdown(ctx)
+			}
+			return nil, nil, err
+		}
+	}
+	if r := cfg.GetSampleRatio(); r > 0 && r < 1 {
+		opts = append(opts, sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(r))))
+	}
+	opts = append(opts, sdktrace.WithResource(resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("fuseftp"),
+		semconv.ServiceVersion(version),
+	)))
+	return sdktrace.NewTracerProvider(opts...), closer, nil
+}
+
+// install makes the given TracerProvider and trace file current, and shuts down the previous ones.
+func (tp *tracerProvider) install(ctx context.Context, sdk *sdktrace.TracerProvider, closer io.Closer) error {
+	tp.Lock()
+	oldSDK, oldCloser := tp.sdk, tp.closer
+	tp.sdk, tp.closer = sdk, closer
+	tp.Unlock()
+	return tp.shutdown(ctx, oldSDK, oldCloser)
+}
+
+func (tp *tracerProvider) shutdown(ctx context.Context, sdk *sdktrace.TracerProvider, closer io.Closer) error {
+	var err error
+	if sdk != nil {
+		err = sdk.Shutdown(ctx)
+	}
+	if closer != nil {
+		err = errors.Join(err, closer.Close())
+	}
+	return err
+}
+
+// close flushes and disables tracing, including the trace file.
+func (tp *tracerProvider) close(ctx context.Context) error {
+	return tp.install(ctx, nil, nil)
+}
+
+func (s *service) ConfigureTracing(ctx context.Context, cfg *rpc.TracingConfig) (*emptypb.Empty, error) {
+	if r := cfg.SampleRatio; r < 0 || r > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "sample ratio %g is not between 0 and 1", r)
+	}
+	if err := s.tracerProvider.configure(ctx, cfg); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &emptypb.Empty{}, nil
+}
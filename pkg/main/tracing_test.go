@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+func TestTracerProvider(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "trace.json")
+	tp := &tracerProvider{file: file}
+
+	// Spans are appended to the trace file when no OTLP endpoint is configured
+	require.NoError(t, tp.configure(ctx, &rpc.TracingConfig{}))
+	_, span := tp.Tracer("test").Start(ctx, "first")
+	span.End()
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"first"`)
+
+	// The trace file is kept when the configuration is replaced
+	require.NoError(t, tp.configure(ctx, &rpc.TracingConfig{SampleRatio: 1}))
+	_, span = tp.Tracer("test").Start(ctx, "second")
+	span.End()
+	data, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"second"`)
+
+	// A trace file that cannot be opened leaves the configuration unchanged, and shuts down the
+	// OTLP exporter that was created for the new configuration
+	tp.file = filepath.Join(t.TempDir(), "nonexistent", "trace.json")
+	assert.Error(t, tp.configure(ctx, &rpc.TracingConfig{OtlpEndpoint: "127.0.0.1:4317", OtlpInsecure: true}))
+	tp.RLock()
+	assert.NotNil(t, tp.sdk)
+	tp.RUnlock()
+
+	// Closing disables tracing, including the trace file
+	require.NoError(t, tp.close(ctx))
+	_, span = tp.Tracer("test").Start(ctx, "third")
+	span.End()
+	data, err = os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"Name":"third"`)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/datawire/go-fuseftp/pkg/fs"
+	"github.com/datawire/go-fuseftp/rpc"
+)
+
+// UpdateMount applies the settings of the request, in the order they are declared, to the live
+// FTP client of the mount. The updated settings are also recorded in the mount's request, so that
+// they are used when the mount is remounted or restored. When a setting cannot be applied, the
+// settings that have been applied are kept, and an error is returned.
+func (s *service) UpdateMount(_ context.Context, rq *rpc.UpdateMountRequest) (*rpc.MountInfo, error) {
+	id := rq.Id.GetId()
+	s.Lock()
+	m, ok := s.mounts[id]
+	s.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "found no mount with id %d", id)
+	}
+	m.updateLock.Lock()
+	defer m.updateLock.Unlock()
+
+	s.Lock()
+	var fc fs.FTPClient
+	if m.stop != nil {
+		fc = m.ftpClient
+	}
+	mrq := proto.Clone(m.request).(*rpc.MountRequest)
+	s.Unlock()
+
+	// apply calls the given function unless the mount is dead, in which case the setting is
+	// only recorded.
+	apply := func(fn func() error) error {
+		if fc == nil {
+			return nil
+		}
+		if err := fn(); err != nil {
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil
+	}
+	var err error
+	if rq.ReadTimeout != nil {
+		if err = apply(func() error { return fc.SetTimeouts(rq.ReadTimeout.AsDuration(), timeouts(mrq)) }); err == nil {
+			mrq.ReadTimeout = rq.ReadTimeout
+		}
+	}
+	if err == nil && rq.Directory != nil {
+		if err = apply(func() error { return fc.SetDirectory(*rq.Directory) }); err == nil {
+			mrq.Directory = *rq.Directory
+		}
+	}
+	if err == nil && rq.Credentials != nil {
+		if err = apply(func() error { return fc.SetCredentials(ftpCredentials(rq.Credentials)) }); err == nil {
+			mrq.Credentials = rq.Credentials
+		}
+	}
+	if err == nil && rq.PoolLimits != nil {
+		_ = apply(func() error {
+			fc.SetPoolLimits(poolLimits(rq.PoolLimits))
+			return nil
+		})
+		mrq.PoolLimits = rq.PoolLimits
+	}
+	if err == nil && rq.RateLimits != nil {
+		_ = apply(func() error {
+			fc.SetRateLimits(rateLimits(rq.RateLimits))
+			return nil
+		})
+	}
+	if err == nil && rq.ReadOnly != nil {
+		if err = apply(func() error { return fc.SetReadOnly(*rq.ReadOnly) }); err == nil {
+			mrq.ReadOnly = *rq.ReadOnly
+		}
+	}
+	if err == nil && rq.AttrCacheTtl != nil {
+		_ = apply(func() error {
+			fc.SetAttrCacheTTL(rq.AttrCacheTtl.AsDuration())
+			return nil
+		})
+		mrq.AttrCacheTtl = rq.AttrCacheTtl
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	m.request = mrq
+	if err == nil && rq.RateLimits != nil {
+		m.rateLimits = proto.Clone(rq.RateLimits).(*rpc.RateLimits)
+	}
+	if s.mounts[id] == m {
+		s.saveState()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m.info(), nil
+}
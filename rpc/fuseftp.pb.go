@@ -11,6 +11,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -22,6 +23,134 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type MountState int32
+
+const (
+	MountState_MOUNT_STATE_UNSPECIFIED MountState = 0
+	// The FUSE host is mounted and serving requests
+	MountState_MOUNT_STATE_MOUNTED MountState = 1
+	// The mount was unmounted by something other than an Unmount call, or the
+	// FUSE host terminated unexpectedly
+	MountState_MOUNT_STATE_DEAD MountState = 2
+	// The mount died and is being remounted
+	MountState_MOUNT_STATE_REMOUNTING MountState = 3
+	// The mount was recorded in the state file, but could not be restored when
+	// the daemon started
+	MountState_MOUNT_STATE_FAILED MountState = 4
+)
+
+// Enum value maps for MountState.
+var (
+	MountState_name = map[int32]string{
+		0: "MOUNT_STATE_UNSPECIFIED",
+		1: "MOUNT_STATE_MOUNTED",
+		2: "MOUNT_STATE_DEAD",
+		3: "MOUNT_STATE_REMOUNTING",
+		4: "MOUNT_STATE_FAILED",
+	}
+	MountState_value = map[string]int32{
+		"MOUNT_STATE_UNSPECIFIED": 0,
+		"MOUNT_STATE_MOUNTED":     1,
+		"MOUNT_STATE_DEAD":        2,
+		"MOUNT_STATE_REMOUNTING":  3,
+		"MOUNT_STATE_FAILED":      4,
+	}
+)
+
+func (x MountState) Enum() *MountState {
+	p := new(MountState)
+	*p = x
+	return p
+}
+
+func (x MountState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MountState) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_fuseftp_proto_enumTypes[0].Descriptor()
+}
+
+func (MountState) Type() protoreflect.EnumType {
+	return &file_rpc_fuseftp_proto_enumTypes[0]
+}
+
+func (x MountState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MountState.Descriptor instead.
+func (MountState) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{0}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED         EventType = 0
+	EventType_EVENT_TYPE_MOUNTED             EventType = 1
+	EventType_EVENT_TYPE_UNMOUNTED           EventType = 2
+	EventType_EVENT_TYPE_CONNECTION_LOST     EventType = 3
+	EventType_EVENT_TYPE_CONNECTION_RESTORED EventType = 4
+	EventType_EVENT_TYPE_ADDRESS_CHANGED     EventType = 5
+	EventType_EVENT_TYPE_TRANSFER_ERROR      EventType = 6
+	EventType_EVENT_TYPE_CACHE_EVICTION      EventType = 7
+	EventType_EVENT_TYPE_DEAD                EventType = 8
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_MOUNTED",
+		2: "EVENT_TYPE_UNMOUNTED",
+		3: "EVENT_TYPE_CONNECTION_LOST",
+		4: "EVENT_TYPE_CONNECTION_RESTORED",
+		5: "EVENT_TYPE_ADDRESS_CHANGED",
+		6: "EVENT_TYPE_TRANSFER_ERROR",
+		7: "EVENT_TYPE_CACHE_EVICTION",
+		8: "EVENT_TYPE_DEAD",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":         0,
+		"EVENT_TYPE_MOUNTED":             1,
+		"EVENT_TYPE_UNMOUNTED":           2,
+		"EVENT_TYPE_CONNECTION_LOST":     3,
+		"EVENT_TYPE_CONNECTION_RESTORED": 4,
+		"EVENT_TYPE_ADDRESS_CHANGED":     5,
+		"EVENT_TYPE_TRANSFER_ERROR":      6,
+		"EVENT_TYPE_CACHE_EVICTION":      7,
+		"EVENT_TYPE_DEAD":                8,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_rpc_fuseftp_proto_enumTypes[1].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_rpc_fuseftp_proto_enumTypes[1]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{1}
+}
+
 type VersionInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -226,25 +355,24 @@ func (x *SetFtpServerRequest) GetFtpServer() *AddressAndPort {
 	return nil
 }
 
-type MountRequest struct {
+// Bandwidth limits in bytes per second. Zero means unlimited.
+type RateLimits struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The mount point on the local computer. Must be a drive letter on windows
-	MountPoint string `protobuf:"bytes,1,opt,name=mount_point,json=mountPoint,proto3" json:"mount_point,omitempty"`
-	// The ftp_server to connect to
-	FtpServer *AddressAndPort `protobuf:"bytes,2,opt,name=ftp_server,json=ftpServer,proto3" json:"ftp_server,omitempty"`
-	// Read timout
-	ReadTimeout *durationpb.Duration `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
-	// The directory on the FTP server that gets mounted
-	Directory string `protobuf:"bytes,4,opt,name=directory,proto3" json:"directory,omitempty"`
-	// The logrus log level
-	LogLevel string `protobuf:"bytes,5,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	// Total upload rate of the mount
+	Upload int64 `protobuf:"varint,1,opt,name=upload,proto3" json:"upload,omitempty"`
+	// Total download rate of the mount
+	Download int64 `protobuf:"varint,2,opt,name=download,proto3" json:"download,omitempty"`
+	// Upload rate of each individual file handle
+	HandleUpload int64 `protobuf:"varint,3,opt,name=handle_upload,json=handleUpload,proto3" json:"handle_upload,omitempty"`
+	// Download rate of each individual file handle
+	HandleDownload int64 `protobuf:"varint,4,opt,name=handle_download,json=handleDownload,proto3" json:"handle_download,omitempty"`
 }
 
-func (x *MountRequest) Reset() {
-	*x = MountRequest{}
+func (x *RateLimits) Reset() {
+	*x = RateLimits{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_rpc_fuseftp_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -252,13 +380,13 @@ func (x *MountRequest) Reset() {
 	}
 }
 
-func (x *MountRequest) String() string {
+func (x *RateLimits) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MountRequest) ProtoMessage() {}
+func (*RateLimits) ProtoMessage() {}
 
-func (x *MountRequest) ProtoReflect() protoreflect.Message {
+func (x *RateLimits) ProtoReflect() protoreflect.Message {
 	mi := &file_rpc_fuseftp_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -270,167 +398,2219 @@ func (x *MountRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MountRequest.ProtoReflect.Descriptor instead.
-func (*MountRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RateLimits.ProtoReflect.Descriptor instead.
+func (*RateLimits) Descriptor() ([]byte, []int) {
 	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *MountRequest) GetMountPoint() string {
+func (x *RateLimits) GetUpload() int64 {
 	if x != nil {
-		return x.MountPoint
+		return x.Upload
+	}
+	return 0
+}
+
+func (x *RateLimits) GetDownload() int64 {
+	if x != nil {
+		return x.Download
+	}
+	return 0
+}
+
+func (x *RateLimits) GetHandleUpload() int64 {
+	if x != nil {
+		return x.HandleUpload
+	}
+	return 0
+}
+
+func (x *RateLimits) GetHandleDownload() int64 {
+	if x != nil {
+		return x.HandleDownload
+	}
+	return 0
+}
+
+// Credentials used when logging in to the FTP server
+type Credentials struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user name. Anonymous login is used when empty.
+	User     string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *Credentials) Reset() {
+	*x = Credentials{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Credentials) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Credentials) ProtoMessage() {}
+
+func (x *Credentials) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Credentials) GetUser() string {
+	if x != nil {
+		return x.User
 	}
 	return ""
 }
 
-func (x *MountRequest) GetFtpServer() *AddressAndPort {
+func (x *Credentials) GetPassword() string {
 	if x != nil {
-		return x.FtpServer
+		return x.Password
+	}
+	return ""
+}
+
+type PoolLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of open connections, busy and idle, above which idle
+	// connections are closed. Zero means the default.
+	MaxIdle int32 `protobuf:"varint,1,opt,name=max_idle,json=maxIdle,proto3" json:"max_idle,omitempty"`
+}
+
+func (x *PoolLimits) Reset() {
+	*x = PoolLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolLimits) ProtoMessage() {}
+
+func (x *PoolLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolLimits.ProtoReflect.Descriptor instead.
+func (*PoolLimits) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PoolLimits) GetMaxIdle() int32 {
+	if x != nil {
+		return x.MaxIdle
+	}
+	return 0
+}
+
+type UpdateMountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// New read timeout. Used as the default for the dial, control, and data idle timeouts.
+	ReadTimeout *durationpb.Duration `protobuf:"bytes,2,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	// New directory on the FTP server. All open file handles are evicted.
+	Directory   *string      `protobuf:"bytes,3,opt,name=directory,proto3,oneof" json:"directory,omitempty"`
+	Credentials *Credentials `protobuf:"bytes,4,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	PoolLimits  *PoolLimits  `protobuf:"bytes,5,opt,name=pool_limits,json=poolLimits,proto3" json:"pool_limits,omitempty"`
+	RateLimits  *RateLimits  `protobuf:"bytes,6,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	// Make the mount read-only or writable. A mount that was mounted read-only must be
+	// remounted to become writable.
+	ReadOnly *bool `protobuf:"varint,7,opt,name=read_only,json=readOnly,proto3,oneof" json:"read_only,omitempty"`
+	// New time that the attributes returned by a directory listing are used to answer lookups.
+	// Applies to the attributes that are already cached.
+	AttrCacheTtl *durationpb.Duration `protobuf:"bytes,8,opt,name=attr_cache_ttl,json=attrCacheTtl,proto3" json:"attr_cache_ttl,omitempty"`
+}
+
+func (x *UpdateMountRequest) Reset() {
+	*x = UpdateMountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateMountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateMountRequest) ProtoMessage() {}
+
+func (x *UpdateMountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateMountRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMountRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateMountRequest) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
 	}
 	return nil
 }
 
-func (x *MountRequest) GetReadTimeout() *durationpb.Duration {
+func (x *UpdateMountRequest) GetReadTimeout() *durationpb.Duration {
 	if x != nil {
 		return x.ReadTimeout
 	}
 	return nil
 }
 
-func (x *MountRequest) GetDirectory() string {
-	if x != nil {
-		return x.Directory
+func (x *UpdateMountRequest) GetDirectory() string {
+	if x != nil && x.Directory != nil {
+		return *x.Directory
 	}
 	return ""
 }
 
-func (x *MountRequest) GetLogLevel() string {
+func (x *UpdateMountRequest) GetCredentials() *Credentials {
 	if x != nil {
-		return x.LogLevel
+		return x.Credentials
 	}
-	return ""
+	return nil
 }
 
-var File_rpc_fuseftp_proto protoreflect.FileDescriptor
+func (x *UpdateMountRequest) GetPoolLimits() *PoolLimits {
+	if x != nil {
+		return x.PoolLimits
+	}
+	return nil
+}
 
-var file_rpc_fuseftp_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x72, 0x70, 0x63, 0x2f, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75,
-	0x73, 0x65, 0x66, 0x74, 0x70, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x22, 0x25, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x22, 0x34, 0x0a, 0x0e, 0x41, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x22,
-	0x21, 0x0a, 0x0f, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
-	0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x46, 0x74, 0x70, 0x53, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72,
-	0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3f, 0x0a,
-	0x0a, 0x66, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73,
-	0x65, 0x66, 0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x64, 0x50,
-	0x6f, 0x72, 0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22, 0xe9,
-	0x01, 0x0a, 0x0c, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74,
-	0x12, 0x3f, 0x0a, 0x0a, 0x66, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e,
-	0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41,
-	0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a,
-	0x09, 0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0xac, 0x02, 0x0a, 0x07, 0x46,
-	0x75, 0x73, 0x65, 0x46, 0x54, 0x50, 0x12, 0x40, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61,
-	0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x05, 0x4d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73,
-	0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73,
-	0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x07, 0x55, 0x6e, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
-	0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66,
-	0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
-	0x65, 0x72, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x65,
-	0x74, 0x46, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x25, 0x2e, 0x64, 0x61, 0x74,
-	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x53, 0x65,
-	0x74, 0x46, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65,
-	0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2f, 0x72, 0x70, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *UpdateMountRequest) GetRateLimits() *RateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
 }
 
-var (
-	file_rpc_fuseftp_proto_rawDescOnce sync.Once
-	file_rpc_fuseftp_proto_rawDescData = file_rpc_fuseftp_proto_rawDesc
-)
+func (x *UpdateMountRequest) GetReadOnly() bool {
+	if x != nil && x.ReadOnly != nil {
+		return *x.ReadOnly
+	}
+	return false
+}
 
-func file_rpc_fuseftp_proto_rawDescGZIP() []byte {
-	file_rpc_fuseftp_proto_rawDescOnce.Do(func() {
-		file_rpc_fuseftp_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_fuseftp_proto_rawDescData)
-	})
-	return file_rpc_fuseftp_proto_rawDescData
+func (x *UpdateMountRequest) GetAttrCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.AttrCacheTtl
+	}
+	return nil
 }
 
-var file_rpc_fuseftp_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_rpc_fuseftp_proto_goTypes = []interface{}{
-	(*VersionInfo)(nil),         // 0: datawire.fuseftp.VersionInfo
-	(*AddressAndPort)(nil),      // 1: datawire.fuseftp.AddressAndPort
-	(*MountIdentifier)(nil),     // 2: datawire.fuseftp.MountIdentifier
-	(*SetFtpServerRequest)(nil), // 3: datawire.fuseftp.SetFtpServerRequest
-	(*MountRequest)(nil),        // 4: datawire.fuseftp.MountRequest
-	(*durationpb.Duration)(nil), // 5: google.protobuf.Duration
-	(*emptypb.Empty)(nil),       // 6: google.protobuf.Empty
+type SetRateLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RateLimits *RateLimits      `protobuf:"bytes,2,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
 }
-var file_rpc_fuseftp_proto_depIdxs = []int32{
-	2, // 0: datawire.fuseftp.SetFtpServerRequest.id:type_name -> datawire.fuseftp.MountIdentifier
-	1, // 1: datawire.fuseftp.SetFtpServerRequest.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
-	1, // 2: datawire.fuseftp.MountRequest.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
-	5, // 3: datawire.fuseftp.MountRequest.read_timeout:type_name -> google.protobuf.Duration
-	6, // 4: datawire.fuseftp.FuseFTP.Version:input_type -> google.protobuf.Empty
-	4, // 5: datawire.fuseftp.FuseFTP.Mount:input_type -> datawire.fuseftp.MountRequest
-	2, // 6: datawire.fuseftp.FuseFTP.Unmount:input_type -> datawire.fuseftp.MountIdentifier
-	3, // 7: datawire.fuseftp.FuseFTP.SetFtpServer:input_type -> datawire.fuseftp.SetFtpServerRequest
-	0, // 8: datawire.fuseftp.FuseFTP.Version:output_type -> datawire.fuseftp.VersionInfo
-	2, // 9: datawire.fuseftp.FuseFTP.Mount:output_type -> datawire.fuseftp.MountIdentifier
-	6, // 10: datawire.fuseftp.FuseFTP.Unmount:output_type -> google.protobuf.Empty
-	6, // 11: datawire.fuseftp.FuseFTP.SetFtpServer:output_type -> google.protobuf.Empty
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+
+func (x *SetRateLimitsRequest) Reset() {
+	*x = SetRateLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func init() { file_rpc_fuseftp_proto_init() }
-func file_rpc_fuseftp_proto_init() {
-	if File_rpc_fuseftp_proto != nil {
-		return
+func (x *SetRateLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRateLimitsRequest) ProtoMessage() {}
+
+func (x *SetRateLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_rpc_fuseftp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRateLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetRateLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SetRateLimitsRequest) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SetRateLimitsRequest) GetRateLimits() *RateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+// Maps FTP replies to an errno, overriding the default mapping.
+type ErrorRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The FTP reply code
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Optional case-insensitive regular expression that the reply message must match
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The errno name, e.g. "EACCES", or its decimal value
+	Errno string `protobuf:"bytes,3,opt,name=errno,proto3" json:"errno,omitempty"`
+}
+
+func (x *ErrorRule) Reset() {
+	*x = ErrorRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorRule) ProtoMessage() {}
+
+func (x *ErrorRule) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorRule.ProtoReflect.Descriptor instead.
+func (*ErrorRule) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ErrorRule) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ErrorRule) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ErrorRule) GetErrno() string {
+	if x != nil {
+		return x.Errno
+	}
+	return ""
+}
+
+type MountRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The mount point on the local computer. Must be a drive letter on windows
+	MountPoint string `protobuf:"bytes,1,opt,name=mount_point,json=mountPoint,proto3" json:"mount_point,omitempty"`
+	// The ftp_server to connect to
+	FtpServer *AddressAndPort `protobuf:"bytes,2,opt,name=ftp_server,json=ftpServer,proto3" json:"ftp_server,omitempty"`
+	// Read timout. Used as the default for the dial, control, and data idle timeouts.
+	ReadTimeout *durationpb.Duration `protobuf:"bytes,3,opt,name=read_timeout,json=readTimeout,proto3" json:"read_timeout,omitempty"`
+	// The directory on the FTP server that gets mounted
+	Directory string `protobuf:"bytes,4,opt,name=directory,proto3" json:"directory,omitempty"`
+	// The logrus log level of the mount. The level of the daemon is used when empty.
+	LogLevel string `protobuf:"bytes,5,opt,name=log_level,json=logLevel,proto3" json:"log_level,omitempty"`
+	// URL of a SOCKS5 (socks5://host:port) or HTTP CONNECT (http://host:port) proxy that
+	// is used for both control and data connections. When empty, the FTP_PROXY and
+	// ALL_PROXY environment variables of the daemon are used.
+	ProxyUrl string `protobuf:"bytes,6,opt,name=proxy_url,json=proxyUrl,proto3" json:"proxy_url,omitempty"`
+	// Bandwidth limits of the mount
+	RateLimits *RateLimits `protobuf:"bytes,7,opt,name=rate_limits,json=rateLimits,proto3" json:"rate_limits,omitempty"`
+	// Maximum time to establish a control or data connection
+	DialTimeout *durationpb.Duration `protobuf:"bytes,8,opt,name=dial_timeout,json=dialTimeout,proto3" json:"dial_timeout,omitempty"`
+	// Maximum time to wait for the server on a control connection
+	ControlTimeout *durationpb.Duration `protobuf:"bytes,9,opt,name=control_timeout,json=controlTimeout,proto3" json:"control_timeout,omitempty"`
+	// Maximum time that a data connection can be idle during a transfer
+	DataIdleTimeout *durationpb.Duration `protobuf:"bytes,10,opt,name=data_idle_timeout,json=dataIdleTimeout,proto3" json:"data_idle_timeout,omitempty"`
+	// Deadline for a complete file system operation. No deadline when unset.
+	OperationTimeout *durationpb.Duration `protobuf:"bytes,11,opt,name=operation_timeout,json=operationTimeout,proto3" json:"operation_timeout,omitempty"`
+	// Rules that override how FTP replies are mapped to errno values. The first
+	// matching rule wins.
+	ErrorRules []*ErrorRule `protobuf:"bytes,12,rep,name=error_rules,json=errorRules,proto3" json:"error_rules,omitempty"`
+	// Remount the FTP server, with exponential backoff, when the mount dies
+	// because it was unmounted by something other than an Unmount call.
+	AutoRemount bool `protobuf:"varint,13,opt,name=auto_remount,json=autoRemount,proto3" json:"auto_remount,omitempty"`
+	// Credentials used when logging in to the FTP server. Anonymous login is used
+	// when unset. The password is never returned by the API.
+	Credentials *Credentials `protobuf:"bytes,14,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	// Limits of the connection pool
+	PoolLimits *PoolLimits `protobuf:"bytes,15,opt,name=pool_limits,json=poolLimits,proto3" json:"pool_limits,omitempty"`
+	// Mount read-only. All modifications fail with EROFS without reaching the FTP server.
+	ReadOnly bool `protobuf:"varint,16,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// Options passed to FUSE when mounting
+	FuseOptions *FuseOptions `protobuf:"bytes,17,opt,name=fuse_options,json=fuseOptions,proto3" json:"fuse_options,omitempty"`
+	// Owner and permissions reported for files and directories
+	Identity *Identity `protobuf:"bytes,18,opt,name=identity,proto3" json:"identity,omitempty"`
+	// Credentials used for calls made by the given local uids. Each uid gets a separate
+	// connection pool. Other uids use the credentials above. The passwords are never
+	// returned by the API.
+	UserCredentials map[uint32]*Credentials `protobuf:"bytes,19,rep,name=user_credentials,json=userCredentials,proto3" json:"user_credentials,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Time that the attributes returned by a directory listing are used to answer the lookups
+	// that follow it, measured from the end of the listing. One second when unset. A negative
+	// duration disables the cache.
+	AttrCacheTtl *durationpb.Duration `protobuf:"bytes,20,opt,name=attr_cache_ttl,json=attrCacheTtl,proto3" json:"attr_cache_ttl,omitempty"`
+	// Check the permissions of each call against the reported owner and mode in the daemon
+	// instead of the kernel. Only the primary group of the caller is considered.
+	AccessChecks bool `protobuf:"varint,21,opt,name=access_checks,json=accessChecks,proto3" json:"access_checks,omitempty"`
+}
+
+func (x *MountRequest) Reset() {
+	*x = MountRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountRequest) ProtoMessage() {}
+
+func (x *MountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountRequest.ProtoReflect.Descriptor instead.
+func (*MountRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MountRequest) GetMountPoint() string {
+	if x != nil {
+		return x.MountPoint
+	}
+	return ""
+}
+
+func (x *MountRequest) GetFtpServer() *AddressAndPort {
+	if x != nil {
+		return x.FtpServer
+	}
+	return nil
+}
+
+func (x *MountRequest) GetReadTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ReadTimeout
+	}
+	return nil
+}
+
+func (x *MountRequest) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *MountRequest) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *MountRequest) GetProxyUrl() string {
+	if x != nil {
+		return x.ProxyUrl
+	}
+	return ""
+}
+
+func (x *MountRequest) GetRateLimits() *RateLimits {
+	if x != nil {
+		return x.RateLimits
+	}
+	return nil
+}
+
+func (x *MountRequest) GetDialTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DialTimeout
+	}
+	return nil
+}
+
+func (x *MountRequest) GetControlTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.ControlTimeout
+	}
+	return nil
+}
+
+func (x *MountRequest) GetDataIdleTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.DataIdleTimeout
+	}
+	return nil
+}
+
+func (x *MountRequest) GetOperationTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.OperationTimeout
+	}
+	return nil
+}
+
+func (x *MountRequest) GetErrorRules() []*ErrorRule {
+	if x != nil {
+		return x.ErrorRules
+	}
+	return nil
+}
+
+func (x *MountRequest) GetAutoRemount() bool {
+	if x != nil {
+		return x.AutoRemount
+	}
+	return false
+}
+
+func (x *MountRequest) GetCredentials() *Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *MountRequest) GetPoolLimits() *PoolLimits {
+	if x != nil {
+		return x.PoolLimits
+	}
+	return nil
+}
+
+func (x *MountRequest) GetReadOnly() bool {
+	if x != nil {
+		return x.ReadOnly
+	}
+	return false
+}
+
+func (x *MountRequest) GetFuseOptions() *FuseOptions {
+	if x != nil {
+		return x.FuseOptions
+	}
+	return nil
+}
+
+func (x *MountRequest) GetIdentity() *Identity {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *MountRequest) GetUserCredentials() map[uint32]*Credentials {
+	if x != nil {
+		return x.UserCredentials
+	}
+	return nil
+}
+
+func (x *MountRequest) GetAttrCacheTtl() *durationpb.Duration {
+	if x != nil {
+		return x.AttrCacheTtl
+	}
+	return nil
+}
+
+func (x *MountRequest) GetAccessChecks() bool {
+	if x != nil {
+		return x.AccessChecks
+	}
+	return false
+}
+
+// Owner and permissions reported for files and directories. By default, everything is
+// owned by the user and group of the daemon, files have mode 0644, and directories have
+// mode 0755.
+type Identity struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Owner and group of everything that isn't found in the owners and groups tables
+	Uid *uint32 `protobuf:"varint,1,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
+	Gid *uint32 `protobuf:"varint,2,opt,name=gid,proto3,oneof" json:"gid,omitempty"`
+	// Permission bits of all files and directories. The default, or the mode reported by
+	// the server when use_server_mode is set, is used when unset.
+	FileMode *uint32 `protobuf:"varint,3,opt,name=file_mode,json=fileMode,proto3,oneof" json:"file_mode,omitempty"`
+	DirMode  *uint32 `protobuf:"varint,4,opt,name=dir_mode,json=dirMode,proto3,oneof" json:"dir_mode,omitempty"`
+	// Permission bits cleared from the mode of all files and directories
+	Umask uint32 `protobuf:"varint,5,opt,name=umask,proto3" json:"umask,omitempty"`
+	// Map the UNIX.owner or UNIX.uid, and the UNIX.group or UNIX.gid, reported by the
+	// server to local ids
+	Owners map[string]uint32 `protobuf:"bytes,6,rep,name=owners,proto3" json:"owners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Groups map[string]uint32 `protobuf:"bytes,7,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Use the UNIX.mode reported by the server, or the mode of an "ls -l" style listing, for
+	// files and directories without a file_mode or dir_mode.
+	UseServerMode bool `protobuf:"varint,8,opt,name=use_server_mode,json=useServerMode,proto3" json:"use_server_mode,omitempty"`
+}
+
+func (x *Identity) Reset() {
+	*x = Identity{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Identity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Identity) ProtoMessage() {}
+
+func (x *Identity) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Identity.ProtoReflect.Descriptor instead.
+func (*Identity) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Identity) GetUid() uint32 {
+	if x != nil && x.Uid != nil {
+		return *x.Uid
+	}
+	return 0
+}
+
+func (x *Identity) GetGid() uint32 {
+	if x != nil && x.Gid != nil {
+		return *x.Gid
+	}
+	return 0
+}
+
+func (x *Identity) GetFileMode() uint32 {
+	if x != nil && x.FileMode != nil {
+		return *x.FileMode
+	}
+	return 0
+}
+
+func (x *Identity) GetDirMode() uint32 {
+	if x != nil && x.DirMode != nil {
+		return *x.DirMode
+	}
+	return 0
+}
+
+func (x *Identity) GetUmask() uint32 {
+	if x != nil {
+		return x.Umask
+	}
+	return 0
+}
+
+func (x *Identity) GetOwners() map[string]uint32 {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Identity) GetGroups() map[string]uint32 {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *Identity) GetUseServerMode() bool {
+	if x != nil {
+		return x.UseServerMode
+	}
+	return false
+}
+
+// Options passed to FUSE when mounting. Options that are unsupported on the platform of the
+// daemon make the Mount call fail with InvalidArgument.
+type FuseOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Give all users access to the mount. Requires user_allow_other in /etc/fuse.conf
+	// on Linux unless the daemon runs as root.
+	AllowOther bool `protobuf:"varint,1,opt,name=allow_other,json=allowOther,proto3" json:"allow_other,omitempty"`
+	// Give root access to the mount. Cannot be combined with allow_other, and has the
+	// same requirements. Root has access when unset, unless allow_other is set or the
+	// daemon runs on Windows.
+	AllowRoot *bool `protobuf:"varint,2,opt,name=allow_root,json=allowRoot,proto3,oneof" json:"allow_root,omitempty"`
+	// Owner and group of all files, and the permission bits that are cleared.
+	Uid   *uint32 `protobuf:"varint,3,opt,name=uid,proto3,oneof" json:"uid,omitempty"`
+	Gid   *uint32 `protobuf:"varint,4,opt,name=gid,proto3,oneof" json:"gid,omitempty"`
+	Umask *uint32 `protobuf:"varint,5,opt,name=umask,proto3,oneof" json:"umask,omitempty"`
+	// Name of the file system, shown as the source of the mount
+	Fsname string `protobuf:"bytes,6,opt,name=fsname,proto3" json:"fsname,omitempty"`
+	// Shown as the part after "fuse." in the file system type
+	Subtype string `protobuf:"bytes,7,opt,name=subtype,proto3" json:"subtype,omitempty"`
+	// Maximum size of a read request. Zero means the FUSE default.
+	MaxRead uint32 `protobuf:"varint,8,opt,name=max_read,json=maxRead,proto3" json:"max_read,omitempty"`
+	// Times that the kernel caches names, attributes, and failed lookups
+	EntryTimeout    *durationpb.Duration `protobuf:"bytes,9,opt,name=entry_timeout,json=entryTimeout,proto3" json:"entry_timeout,omitempty"`
+	AttrTimeout     *durationpb.Duration `protobuf:"bytes,10,opt,name=attr_timeout,json=attrTimeout,proto3" json:"attr_timeout,omitempty"`
+	NegativeTimeout *durationpb.Duration `protobuf:"bytes,11,opt,name=negative_timeout,json=negativeTimeout,proto3" json:"negative_timeout,omitempty"`
+	// Additional options in the "name" or "name=value" form
+	Extra []string `protobuf:"bytes,12,rep,name=extra,proto3" json:"extra,omitempty"`
+}
+
+func (x *FuseOptions) Reset() {
+	*x = FuseOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FuseOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FuseOptions) ProtoMessage() {}
+
+func (x *FuseOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FuseOptions.ProtoReflect.Descriptor instead.
+func (*FuseOptions) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FuseOptions) GetAllowOther() bool {
+	if x != nil {
+		return x.AllowOther
+	}
+	return false
+}
+
+func (x *FuseOptions) GetAllowRoot() bool {
+	if x != nil && x.AllowRoot != nil {
+		return *x.AllowRoot
+	}
+	return false
+}
+
+func (x *FuseOptions) GetUid() uint32 {
+	if x != nil && x.Uid != nil {
+		return *x.Uid
+	}
+	return 0
+}
+
+func (x *FuseOptions) GetGid() uint32 {
+	if x != nil && x.Gid != nil {
+		return *x.Gid
+	}
+	return 0
+}
+
+func (x *FuseOptions) GetUmask() uint32 {
+	if x != nil && x.Umask != nil {
+		return *x.Umask
+	}
+	return 0
+}
+
+func (x *FuseOptions) GetFsname() string {
+	if x != nil {
+		return x.Fsname
+	}
+	return ""
+}
+
+func (x *FuseOptions) GetSubtype() string {
+	if x != nil {
+		return x.Subtype
+	}
+	return ""
+}
+
+func (x *FuseOptions) GetMaxRead() uint32 {
+	if x != nil {
+		return x.MaxRead
+	}
+	return 0
+}
+
+func (x *FuseOptions) GetEntryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.EntryTimeout
+	}
+	return nil
+}
+
+func (x *FuseOptions) GetAttrTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.AttrTimeout
+	}
+	return nil
+}
+
+func (x *FuseOptions) GetNegativeTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.NegativeTimeout
+	}
+	return nil
+}
+
+func (x *FuseOptions) GetExtra() []string {
+	if x != nil {
+		return x.Extra
+	}
+	return nil
+}
+
+type MountInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The mount point on the local computer
+	MountPoint string `protobuf:"bytes,2,opt,name=mount_point,json=mountPoint,proto3" json:"mount_point,omitempty"`
+	// The FTP server that the mount currently uses
+	FtpServer *AddressAndPort `protobuf:"bytes,3,opt,name=ftp_server,json=ftpServer,proto3" json:"ftp_server,omitempty"`
+	// The directory on the FTP server that is mounted
+	Directory string `protobuf:"bytes,4,opt,name=directory,proto3" json:"directory,omitempty"`
+	// The options that the mount was created with
+	Options *MountRequest `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	// Time elapsed since the mount was created
+	Uptime *durationpb.Duration `protobuf:"bytes,6,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	State  MountState           `protobuf:"varint,7,opt,name=state,proto3,enum=datawire.fuseftp.MountState" json:"state,omitempty"`
+	// Why the mount is dead or being remounted, or the error from the last
+	// remount attempt
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *MountInfo) Reset() {
+	*x = MountInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MountInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountInfo) ProtoMessage() {}
+
+func (x *MountInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountInfo.ProtoReflect.Descriptor instead.
+func (*MountInfo) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MountInfo) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MountInfo) GetMountPoint() string {
+	if x != nil {
+		return x.MountPoint
+	}
+	return ""
+}
+
+func (x *MountInfo) GetFtpServer() *AddressAndPort {
+	if x != nil {
+		return x.FtpServer
+	}
+	return nil
+}
+
+func (x *MountInfo) GetDirectory() string {
+	if x != nil {
+		return x.Directory
+	}
+	return ""
+}
+
+func (x *MountInfo) GetOptions() *MountRequest {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *MountInfo) GetUptime() *durationpb.Duration {
+	if x != nil {
+		return x.Uptime
+	}
+	return nil
+}
+
+func (x *MountInfo) GetState() MountState {
+	if x != nil {
+		return x.State
+	}
+	return MountState_MOUNT_STATE_UNSPECIFIED
+}
+
+func (x *MountInfo) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type MountList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mounts []*MountInfo `protobuf:"bytes,1,rep,name=mounts,proto3" json:"mounts,omitempty"`
+}
+
+func (x *MountList) Reset() {
+	*x = MountList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MountList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountList) ProtoMessage() {}
+
+func (x *MountList) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountList.ProtoReflect.Descriptor instead.
+func (*MountList) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MountList) GetMounts() []*MountInfo {
+	if x != nil {
+		return x.Mounts
+	}
+	return nil
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Only stream the events of this mount when set
+	Id *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *WatchEventsRequest) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+type MountEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   *MountIdentifier       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type EventType              `protobuf:"varint,2,opt,name=type,proto3,enum=datawire.fuseftp.EventType" json:"type,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// The path affected by a transfer error or cache eviction
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// The new FTP server when the address changed
+	FtpServer *AddressAndPort `protobuf:"bytes,5,opt,name=ftp_server,json=ftpServer,proto3" json:"ftp_server,omitempty"`
+	// Details, such as the text of an error
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	// Number of events that were discarded before this one because the client didn't keep up
+	Dropped uint64 `protobuf:"varint,7,opt,name=dropped,proto3" json:"dropped,omitempty"`
+}
+
+func (x *MountEvent) Reset() {
+	*x = MountEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MountEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountEvent) ProtoMessage() {}
+
+func (x *MountEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountEvent.ProtoReflect.Descriptor instead.
+func (*MountEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MountEvent) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MountEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *MountEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *MountEvent) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MountEvent) GetFtpServer() *AddressAndPort {
+	if x != nil {
+		return x.FtpServer
+	}
+	return nil
+}
+
+func (x *MountEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MountEvent) GetDropped() uint64 {
+	if x != nil {
+		return x.Dropped
+	}
+	return 0
+}
+
+type LatencyBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Upper bound of the bucket. Unset for the last bucket, which has no upper bound.
+	UpperBound *durationpb.Duration `protobuf:"bytes,1,opt,name=upper_bound,json=upperBound,proto3" json:"upper_bound,omitempty"`
+	// Number of calls with a latency that exceeds the bound of the previous bucket but not
+	// the upper_bound of this bucket
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *LatencyBucket) Reset() {
+	*x = LatencyBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatencyBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatencyBucket) ProtoMessage() {}
+
+func (x *LatencyBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatencyBucket.ProtoReflect.Descriptor instead.
+func (*LatencyBucket) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LatencyBucket) GetUpperBound() *durationpb.Duration {
+	if x != nil {
+		return x.UpperBound
+	}
+	return nil
+}
+
+func (x *LatencyBucket) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type OperationStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of calls
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Number of calls that returned an error
+	Errors uint64 `protobuf:"varint,2,opt,name=errors,proto3" json:"errors,omitempty"`
+	// Sum of the latencies of all calls
+	TotalLatency *durationpb.Duration `protobuf:"bytes,3,opt,name=total_latency,json=totalLatency,proto3" json:"total_latency,omitempty"`
+	// Latency histogram
+	Latency []*LatencyBucket `protobuf:"bytes,4,rep,name=latency,proto3" json:"latency,omitempty"`
+}
+
+func (x *OperationStats) Reset() {
+	*x = OperationStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperationStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationStats) ProtoMessage() {}
+
+func (x *OperationStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationStats.ProtoReflect.Descriptor instead.
+func (*OperationStats) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *OperationStats) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *OperationStats) GetErrors() uint64 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *OperationStats) GetTotalLatency() *durationpb.Duration {
+	if x != nil {
+		return x.TotalLatency
+	}
+	return nil
+}
+
+func (x *OperationStats) GetLatency() []*LatencyBucket {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+type PoolStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of idle connections
+	Idle int32 `protobuf:"varint,1,opt,name=idle,proto3" json:"idle,omitempty"`
+	// Number of connections in use
+	Busy int32 `protobuf:"varint,2,opt,name=busy,proto3" json:"busy,omitempty"`
+	// Number of attempts to dial and log in to the FTP server
+	Dials uint64 `protobuf:"varint,3,opt,name=dials,proto3" json:"dials,omitempty"`
+	// Number of dial attempts that failed
+	DialFailures uint64 `protobuf:"varint,4,opt,name=dial_failures,json=dialFailures,proto3" json:"dial_failures,omitempty"`
+}
+
+func (x *PoolStats) Reset() {
+	*x = PoolStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PoolStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PoolStats) ProtoMessage() {}
+
+func (x *PoolStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PoolStats.ProtoReflect.Descriptor instead.
+func (*PoolStats) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PoolStats) GetIdle() int32 {
+	if x != nil {
+		return x.Idle
+	}
+	return 0
+}
+
+func (x *PoolStats) GetBusy() int32 {
+	if x != nil {
+		return x.Busy
+	}
+	return 0
+}
+
+func (x *PoolStats) GetDials() uint64 {
+	if x != nil {
+		return x.Dials
+	}
+	return 0
+}
+
+func (x *PoolStats) GetDialFailures() uint64 {
+	if x != nil {
+		return x.DialFailures
+	}
+	return 0
+}
+
+type MountStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Statistics per FUSE operation, keyed by operation name, e.g. "Getattr"
+	Operations map[string]*OperationStats `protobuf:"bytes,2,rep,name=operations,proto3" json:"operations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Pool       *PoolStats                 `protobuf:"bytes,3,opt,name=pool,proto3" json:"pool,omitempty"`
+	// Number of bytes read from files on the FTP server
+	BytesRead uint64 `protobuf:"varint,4,opt,name=bytes_read,json=bytesRead,proto3" json:"bytes_read,omitempty"`
+	// Number of bytes written to files on the FTP server
+	BytesWritten uint64 `protobuf:"varint,5,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+	// Number of open file handles
+	OpenHandles int32 `protobuf:"varint,6,opt,name=open_handles,json=openHandles,proto3" json:"open_handles,omitempty"`
+	// Number of failed operations keyed by errno name, e.g. "ENOENT"
+	ErrorsByErrno map[string]uint64 `protobuf:"bytes,7,rep,name=errors_by_errno,json=errorsByErrno,proto3" json:"errors_by_errno,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Number of failed FTP commands keyed by reply code
+	ErrorsByReplyCode map[int32]uint64 `protobuf:"bytes,8,rep,name=errors_by_reply_code,json=errorsByReplyCode,proto3" json:"errors_by_reply_code,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *MountStats) Reset() {
+	*x = MountStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MountStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MountStats) ProtoMessage() {}
+
+func (x *MountStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MountStats.ProtoReflect.Descriptor instead.
+func (*MountStats) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MountStats) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *MountStats) GetOperations() map[string]*OperationStats {
+	if x != nil {
+		return x.Operations
+	}
+	return nil
+}
+
+func (x *MountStats) GetPool() *PoolStats {
+	if x != nil {
+		return x.Pool
+	}
+	return nil
+}
+
+func (x *MountStats) GetBytesRead() uint64 {
+	if x != nil {
+		return x.BytesRead
+	}
+	return 0
+}
+
+func (x *MountStats) GetBytesWritten() uint64 {
+	if x != nil {
+		return x.BytesWritten
+	}
+	return 0
+}
+
+func (x *MountStats) GetOpenHandles() int32 {
+	if x != nil {
+		return x.OpenHandles
+	}
+	return 0
+}
+
+func (x *MountStats) GetErrorsByErrno() map[string]uint64 {
+	if x != nil {
+		return x.ErrorsByErrno
+	}
+	return nil
+}
+
+func (x *MountStats) GetErrorsByReplyCode() map[int32]uint64 {
+	if x != nil {
+		return x.ErrorsByReplyCode
+	}
+	return nil
+}
+
+type TracingConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Address (host:port) of an OTLP/gRPC endpoint that spans are exported to
+	OtlpEndpoint string `protobuf:"bytes,1,opt,name=otlp_endpoint,json=otlpEndpoint,proto3" json:"otlp_endpoint,omitempty"`
+	// Use a plaintext connection to the OTLP endpoint
+	OtlpInsecure bool `protobuf:"varint,2,opt,name=otlp_insecure,json=otlpInsecure,proto3" json:"otlp_insecure,omitempty"`
+	// Fraction of traces that are sampled, between 0 and 1. Zero means that all traces are sampled.
+	SampleRatio float64 `protobuf:"fixed64,3,opt,name=sample_ratio,json=sampleRatio,proto3" json:"sample_ratio,omitempty"`
+}
+
+func (x *TracingConfig) Reset() {
+	*x = TracingConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TracingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TracingConfig) ProtoMessage() {}
+
+func (x *TracingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TracingConfig.ProtoReflect.Descriptor instead.
+func (*TracingConfig) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TracingConfig) GetOtlpEndpoint() string {
+	if x != nil {
+		return x.OtlpEndpoint
+	}
+	return ""
+}
+
+func (x *TracingConfig) GetOtlpInsecure() bool {
+	if x != nil {
+		return x.OtlpInsecure
+	}
+	return false
+}
+
+func (x *TracingConfig) GetSampleRatio() float64 {
+	if x != nil {
+		return x.SampleRatio
+	}
+	return 0
+}
+
+type SetLogLevelRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The mount, or unset for the daemon
+	Id *MountIdentifier `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The logrus log level
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_fuseftp_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_fuseftp_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_fuseftp_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetLogLevelRequest) GetId() *MountIdentifier {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+var File_rpc_fuseftp_proto protoreflect.FileDescriptor
+
+var file_rpc_fuseftp_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x72, 0x70, 0x63, 0x2f, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x10, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75,
+	0x73, 0x65, 0x66, 0x74, 0x70, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x25, 0x0a, 0x0b, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x22, 0x34, 0x0a, 0x0e, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x22, 0x21, 0x0a, 0x0f, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x46, 0x74, 0x70, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74,
+	0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3f,
+	0x0a, 0x0a, 0x66, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75,
+	0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x64,
+	0x50, 0x6f, 0x72, 0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x22,
+	0x8e, 0x01, 0x0a, 0x0a, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
+	0x61, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x68, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64,
+	0x22, 0x3d, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x75, 0x73, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22,
+	0x27, 0x0a, 0x0a, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x19, 0x0a,
+	0x08, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x22, 0xe6, 0x03, 0x0a, 0x12, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x31, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x12, 0x21, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79,
+	0x88, 0x01, 0x01, 0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x61, 0x6c, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x61, 0x74, 0x61,
+	0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x50, 0x6f, 0x6f,
+	0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x70, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x12, 0x20, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x48, 0x01, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4f, 0x6e, 0x6c,
+	0x79, 0x88, 0x01, 0x01, 0x12, 0x3f, 0x0a, 0x0e, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x63, 0x61, 0x63,
+	0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x72, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x54, 0x74, 0x6c, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x79, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x22, 0x88, 0x01, 0x0a, 0x14, 0x53, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3d, 0x0a,
+	0x0b, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75,
+	0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x52, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x4f, 0x0a, 0x09,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6e, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6e, 0x6f, 0x22, 0xf7, 0x09,
+	0x0a, 0x0c, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12,
+	0x3f, 0x0a, 0x0a, 0x66, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66,
+	0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e,
+	0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x12, 0x3c, 0x0a, 0x0c, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x72, 0x65, 0x61, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09,
+	0x6c, 0x6f, 0x67, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x72, 0x6f,
+	0x78, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72,
+	0x6f, 0x78, 0x79, 0x55, 0x72, 0x6c, 0x12, 0x3d, 0x0a, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x52,
+	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x72, 0x61, 0x74, 0x65, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x3c, 0x0a, 0x0c, 0x64, 0x69, 0x61, 0x6c, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x42, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x45, 0x0a, 0x11, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x69, 0x64, 0x6c, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x64,
+	0x61, 0x74, 0x61, 0x49, 0x64, 0x6c, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x46,
+	0x0a, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x0b, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x75, 0x6c, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x72, 0x65, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x6f,
+	0x52, 0x65, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b, 0x63, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x3d, 0x0a, 0x0b, 0x70, 0x6f, 0x6f, 0x6c,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0a, 0x70, 0x6f, 0x6f,
+	0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x64, 0x5f,
+	0x6f, 0x6e, 0x6c, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64,
+	0x4f, 0x6e, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x0c, 0x66, 0x75, 0x73, 0x65, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x46, 0x75,
+	0x73, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0b, 0x66, 0x75, 0x73, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
+	0x74, 0x79, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x52, 0x08, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x5e,
+	0x0a, 0x10, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x75,
+	0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x3f,
+	0x0a, 0x0e, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x5f, 0x74, 0x74, 0x6c,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x54, 0x74, 0x6c, 0x12,
+	0x23, 0x0a, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x1a, 0x61, 0x0a, 0x14, 0x55, 0x73, 0x65, 0x72, 0x43, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x33,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd9, 0x03, 0x0a, 0x08, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x00, 0x52, 0x03, 0x75, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x67,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x03, 0x67, 0x69, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x20, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x1e, 0x0a, 0x08, 0x64, 0x69, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52, 0x07, 0x64, 0x69, 0x72, 0x4d, 0x6f, 0x64,
+	0x65, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x12, 0x3e, 0x0a, 0x06, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x3e, 0x0a, 0x06, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x75, 0x73,
+	0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x75, 0x73, 0x65, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4d, 0x6f,
+	0x64, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a,
+	0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x69, 0x64,
+	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x67, 0x69, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x64, 0x69, 0x72, 0x5f, 0x6d,
+	0x6f, 0x64, 0x65, 0x22, 0xeb, 0x03, 0x0a, 0x0b, 0x46, 0x75, 0x73, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6f, 0x74, 0x68,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x4f,
+	0x74, 0x68, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x72, 0x6f,
+	0x6f, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x52, 0x6f, 0x6f, 0x74, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x03, 0x75, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x15, 0x0a, 0x03, 0x67, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x02, 0x52, 0x03,
+	0x67, 0x69, 0x64, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x03, 0x52, 0x05, 0x75, 0x6d, 0x61, 0x73, 0x6b, 0x88, 0x01,
+	0x01, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x73, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x73, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x61, 0x64, 0x12, 0x3e,
+	0x0a, 0x0d, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c,
+	0x0a, 0x0c, 0x61, 0x74, 0x74, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0b, 0x61, 0x74, 0x74, 0x72, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x44, 0x0a, 0x10,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0f, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x18, 0x0c, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x78, 0x74, 0x72, 0x61, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x69, 0x64, 0x42,
+	0x06, 0x0a, 0x04, 0x5f, 0x67, 0x69, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x75, 0x6d, 0x61, 0x73,
+	0x6b, 0x22, 0xf5, 0x02, 0x0a, 0x09, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x31, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d,
+	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0a, 0x66, 0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69,
+	0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x41, 0x6e, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x79, 0x12, 0x38, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66,
+	0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x31, 0x0a, 0x06,
+	0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x12,
+	0x32, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x40, 0x0a, 0x09, 0x4d, 0x6f, 0x75,
+	0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x06, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72,
+	0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x06, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x22, 0x47, 0x0a, 0x12, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x31, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0xa9, 0x02, 0x0a, 0x0a, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66,
+	0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x3f, 0x0a, 0x0a, 0x66,
+	0x74, 0x70, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66,
+	0x74, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x41, 0x6e, 0x64, 0x50, 0x6f, 0x72,
+	0x74, 0x52, 0x09, 0x66, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64,
+	0x22, 0x61, 0x0a, 0x0d, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x3a, 0x0a, 0x0b, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0a, 0x75, 0x70, 0x70, 0x65, 0x72, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xb9, 0x01, 0x0a, 0x0e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x61,
+	0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x39, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x22,
+	0x6e, 0x0a, 0x09, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x69, 0x64, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x62, 0x75, 0x73, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x62, 0x75, 0x73, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x64, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69,
+	0x61, 0x6c, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0c, 0x64, 0x69, 0x61, 0x6c, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x22,
+	0xcd, 0x05, 0x0a, 0x0a, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x31,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x4c, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x2f, 0x0a, 0x04, 0x70, 0x6f, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x50, 0x6f, 0x6f, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04, 0x70, 0x6f, 0x6f, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x79, 0x74, 0x65, 0x73, 0x52, 0x65, 0x61, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x77, 0x72, 0x69, 0x74, 0x74, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x79, 0x74, 0x65, 0x73, 0x57, 0x72, 0x69,
+	0x74, 0x74, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x68, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6f, 0x70, 0x65, 0x6e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x57, 0x0a, 0x0f, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x5f, 0x62, 0x79, 0x5f, 0x65, 0x72, 0x72, 0x6e, 0x6f, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65,
+	0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x45, 0x72, 0x72, 0x6e, 0x6f, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0d, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x45, 0x72, 0x72, 0x6e, 0x6f,
+	0x12, 0x64, 0x0a, 0x14, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x5f, 0x62, 0x79, 0x5f, 0x72, 0x65,
+	0x70, 0x6c, 0x79, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x33,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x42, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x11, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x42, 0x79, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x1a, 0x5f, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x36, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x42, 0x79, 0x45, 0x72, 0x72, 0x6e, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x44, 0x0a, 0x16, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x42, 0x79, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x43, 0x6f, 0x64, 0x65, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x7c, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x74, 0x6c, 0x70, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6f, 0x74, 0x6c, 0x70, 0x45, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x74, 0x6c, 0x70, 0x5f, 0x69, 0x6e,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6f, 0x74,
+	0x6c, 0x70, 0x49, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x22, 0x5d, 0x0a,
+	0x12, 0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66,
+	0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69,
+	0x65, 0x72, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x2a, 0x8c, 0x01, 0x0a,
+	0x0a, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4d,
+	0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x4f, 0x55, 0x4e,
+	0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x44, 0x10,
+	0x01, 0x12, 0x14, 0x0a, 0x10, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x44, 0x45, 0x41, 0x44, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x4f, 0x55, 0x4e, 0x54,
+	0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x49, 0x4e,
+	0x47, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x04, 0x2a, 0x90, 0x02, 0x0a, 0x09,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x18, 0x0a,
+	0x14, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4d, 0x4f,
+	0x55, 0x4e, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4c, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x4e, 0x4e, 0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x52, 0x45, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x44, 0x10, 0x04, 0x12, 0x1e, 0x0a, 0x1a, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x44, 0x44, 0x52, 0x45, 0x53,
+	0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x05, 0x12, 0x1d, 0x0a, 0x19, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x4e, 0x53, 0x46,
+	0x45, 0x52, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x06, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x43, 0x48, 0x45, 0x5f, 0x45,
+	0x56, 0x49, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x07, 0x12, 0x13, 0x0a, 0x0f, 0x45, 0x56, 0x45,
+	0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x41, 0x44, 0x10, 0x08, 0x32, 0x9a,
+	0x07, 0x0a, 0x07, 0x46, 0x75, 0x73, 0x65, 0x46, 0x54, 0x50, 0x12, 0x40, 0x0a, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x4a, 0x0a, 0x05,
+	0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1e, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65,
+	0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x44, 0x0a, 0x07, 0x55, 0x6e, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x21, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66,
+	0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4d,
+	0x0a, 0x0c, 0x53, 0x65, 0x74, 0x46, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x25,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x53, 0x65, 0x74, 0x46, 0x74, 0x70, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4f, 0x0a,
+	0x0d, 0x53, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x26,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x53, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x41,
+	0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e,
+	0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x4c, 0x69, 0x73,
+	0x74, 0x12, 0x4a, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70,
+	0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x1a, 0x1b, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65,
+	0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x53, 0x0a,
+	0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75,
+	0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x21,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
+	0x72, 0x1a, 0x1c, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73,
+	0x65, 0x66, 0x74, 0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x4b, 0x0a, 0x10, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x54, 0x72, 0x61, 0x63,
+	0x69, 0x6e, 0x67, 0x12, 0x1f, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66,
+	0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4b, 0x0a, 0x0b,
+	0x53, 0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x24, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x53,
+	0x65, 0x74, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x50, 0x0a, 0x0b, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x77,
+	0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2e, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69, 0x72, 0x65, 0x2e, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74,
+	0x70, 0x2e, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x24, 0x5a, 0x22, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x77, 0x69,
+	0x72, 0x65, 0x2f, 0x67, 0x6f, 0x2d, 0x66, 0x75, 0x73, 0x65, 0x66, 0x74, 0x70, 0x2f, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_fuseftp_proto_rawDescOnce sync.Once
+	file_rpc_fuseftp_proto_rawDescData = file_rpc_fuseftp_proto_rawDesc
+)
+
+func file_rpc_fuseftp_proto_rawDescGZIP() []byte {
+	file_rpc_fuseftp_proto_rawDescOnce.Do(func() {
+		file_rpc_fuseftp_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_fuseftp_proto_rawDescData)
+	})
+	return file_rpc_fuseftp_proto_rawDescData
+}
+
+var file_rpc_fuseftp_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_rpc_fuseftp_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_rpc_fuseftp_proto_goTypes = []interface{}{
+	(MountState)(0),               // 0: datawire.fuseftp.MountState
+	(EventType)(0),                // 1: datawire.fuseftp.EventType
+	(*VersionInfo)(nil),           // 2: datawire.fuseftp.VersionInfo
+	(*AddressAndPort)(nil),        // 3: datawire.fuseftp.AddressAndPort
+	(*MountIdentifier)(nil),       // 4: datawire.fuseftp.MountIdentifier
+	(*SetFtpServerRequest)(nil),   // 5: datawire.fuseftp.SetFtpServerRequest
+	(*RateLimits)(nil),            // 6: datawire.fuseftp.RateLimits
+	(*Credentials)(nil),           // 7: datawire.fuseftp.Credentials
+	(*PoolLimits)(nil),            // 8: datawire.fuseftp.PoolLimits
+	(*UpdateMountRequest)(nil),    // 9: datawire.fuseftp.UpdateMountRequest
+	(*SetRateLimitsRequest)(nil),  // 10: datawire.fuseftp.SetRateLimitsRequest
+	(*ErrorRule)(nil),             // 11: datawire.fuseftp.ErrorRule
+	(*MountRequest)(nil),          // 12: datawire.fuseftp.MountRequest
+	(*Identity)(nil),              // 13: datawire.fuseftp.Identity
+	(*FuseOptions)(nil),           // 14: datawire.fuseftp.FuseOptions
+	(*MountInfo)(nil),             // 15: datawire.fuseftp.MountInfo
+	(*MountList)(nil),             // 16: datawire.fuseftp.MountList
+	(*WatchEventsRequest)(nil),    // 17: datawire.fuseftp.WatchEventsRequest
+	(*MountEvent)(nil),            // 18: datawire.fuseftp.MountEvent
+	(*LatencyBucket)(nil),         // 19: datawire.fuseftp.LatencyBucket
+	(*OperationStats)(nil),        // 20: datawire.fuseftp.OperationStats
+	(*PoolStats)(nil),             // 21: datawire.fuseftp.PoolStats
+	(*MountStats)(nil),            // 22: datawire.fuseftp.MountStats
+	(*TracingConfig)(nil),         // 23: datawire.fuseftp.TracingConfig
+	(*SetLogLevelRequest)(nil),    // 24: datawire.fuseftp.SetLogLevelRequest
+	nil,                           // 25: datawire.fuseftp.MountRequest.UserCredentialsEntry
+	nil,                           // 26: datawire.fuseftp.Identity.OwnersEntry
+	nil,                           // 27: datawire.fuseftp.Identity.GroupsEntry
+	nil,                           // 28: datawire.fuseftp.MountStats.OperationsEntry
+	nil,                           // 29: datawire.fuseftp.MountStats.ErrorsByErrnoEntry
+	nil,                           // 30: datawire.fuseftp.MountStats.ErrorsByReplyCodeEntry
+	(*durationpb.Duration)(nil),   // 31: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 32: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 33: google.protobuf.Empty
+}
+var file_rpc_fuseftp_proto_depIdxs = []int32{
+	4,  // 0: datawire.fuseftp.SetFtpServerRequest.id:type_name -> datawire.fuseftp.MountIdentifier
+	3,  // 1: datawire.fuseftp.SetFtpServerRequest.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
+	4,  // 2: datawire.fuseftp.UpdateMountRequest.id:type_name -> datawire.fuseftp.MountIdentifier
+	31, // 3: datawire.fuseftp.UpdateMountRequest.read_timeout:type_name -> google.protobuf.Duration
+	7,  // 4: datawire.fuseftp.UpdateMountRequest.credentials:type_name -> datawire.fuseftp.Credentials
+	8,  // 5: datawire.fuseftp.UpdateMountRequest.pool_limits:type_name -> datawire.fuseftp.PoolLimits
+	6,  // 6: datawire.fuseftp.UpdateMountRequest.rate_limits:type_name -> datawire.fuseftp.RateLimits
+	31, // 7: datawire.fuseftp.UpdateMountRequest.attr_cache_ttl:type_name -> google.protobuf.Duration
+	4,  // 8: datawire.fuseftp.SetRateLimitsRequest.id:type_name -> datawire.fuseftp.MountIdentifier
+	6,  // 9: datawire.fuseftp.SetRateLimitsRequest.rate_limits:type_name -> datawire.fuseftp.RateLimits
+	3,  // 10: datawire.fuseftp.MountRequest.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
+	31, // 11: datawire.fuseftp.MountRequest.read_timeout:type_name -> google.protobuf.Duration
+	6,  // 12: datawire.fuseftp.MountRequest.rate_limits:type_name -> datawire.fuseftp.RateLimits
+	31, // 13: datawire.fuseftp.MountRequest.dial_timeout:type_name -> google.protobuf.Duration
+	31, // 14: datawire.fuseftp.MountRequest.control_timeout:type_name -> google.protobuf.Duration
+	31, // 15: datawire.fuseftp.MountRequest.data_idle_timeout:type_name -> google.protobuf.Duration
+	31, // 16: datawire.fuseftp.MountRequest.operation_timeout:type_name -> google.protobuf.Duration
+	11, // 17: datawire.fuseftp.MountRequest.error_rules:type_name -> datawire.fuseftp.ErrorRule
+	7,  // 18: datawire.fuseftp.MountRequest.credentials:type_name -> datawire.fuseftp.Credentials
+	8,  // 19: datawire.fuseftp.MountRequest.pool_limits:type_name -> datawire.fuseftp.PoolLimits
+	14, // 20: datawire.fuseftp.MountRequest.fuse_options:type_name -> datawire.fuseftp.FuseOptions
+	13, // 21: datawire.fuseftp.MountRequest.identity:type_name -> datawire.fuseftp.Identity
+	25, // 22: datawire.fuseftp.MountRequest.user_credentials:type_name -> datawire.fuseftp.MountRequest.UserCredentialsEntry
+	31, // 23: datawire.fuseftp.MountRequest.attr_cache_ttl:type_name -> google.protobuf.Duration
+	26, // 24: datawire.fuseftp.Identity.owners:type_name -> datawire.fuseftp.Identity.OwnersEntry
+	27, // 25: datawire.fuseftp.Identity.groups:type_name -> datawire.fuseftp.Identity.GroupsEntry
+	31, // 26: datawire.fuseftp.FuseOptions.entry_timeout:type_name -> google.protobuf.Duration
+	31, // 27: datawire.fuseftp.FuseOptions.attr_timeout:type_name -> google.protobuf.Duration
+	31, // 28: datawire.fuseftp.FuseOptions.negative_timeout:type_name -> google.protobuf.Duration
+	4,  // 29: datawire.fuseftp.MountInfo.id:type_name -> datawire.fuseftp.MountIdentifier
+	3,  // 30: datawire.fuseftp.MountInfo.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
+	12, // 31: datawire.fuseftp.MountInfo.options:type_name -> datawire.fuseftp.MountRequest
+	31, // 32: datawire.fuseftp.MountInfo.uptime:type_name -> google.protobuf.Duration
+	0,  // 33: datawire.fuseftp.MountInfo.state:type_name -> datawire.fuseftp.MountState
+	15, // 34: datawire.fuseftp.MountList.mounts:type_name -> datawire.fuseftp.MountInfo
+	4,  // 35: datawire.fuseftp.WatchEventsRequest.id:type_name -> datawire.fuseftp.MountIdentifier
+	4,  // 36: datawire.fuseftp.MountEvent.id:type_name -> datawire.fuseftp.MountIdentifier
+	1,  // 37: datawire.fuseftp.MountEvent.type:type_name -> datawire.fuseftp.EventType
+	32, // 38: datawire.fuseftp.MountEvent.time:type_name -> google.protobuf.Timestamp
+	3,  // 39: datawire.fuseftp.MountEvent.ftp_server:type_name -> datawire.fuseftp.AddressAndPort
+	31, // 40: datawire.fuseftp.LatencyBucket.upper_bound:type_name -> google.protobuf.Duration
+	31, // 41: datawire.fuseftp.OperationStats.total_latency:type_name -> google.protobuf.Duration
+	19, // 42: datawire.fuseftp.OperationStats.latency:type_name -> datawire.fuseftp.LatencyBucket
+	4,  // 43: datawire.fuseftp.MountStats.id:type_name -> datawire.fuseftp.MountIdentifier
+	28, // 44: datawire.fuseftp.MountStats.operations:type_name -> datawire.fuseftp.MountStats.OperationsEntry
+	21, // 45: datawire.fuseftp.MountStats.pool:type_name -> datawire.fuseftp.PoolStats
+	29, // 46: datawire.fuseftp.MountStats.errors_by_errno:type_name -> datawire.fuseftp.MountStats.ErrorsByErrnoEntry
+	30, // 47: datawire.fuseftp.MountStats.errors_by_reply_code:type_name -> datawire.fuseftp.MountStats.ErrorsByReplyCodeEntry
+	4,  // 48: datawire.fuseftp.SetLogLevelRequest.id:type_name -> datawire.fuseftp.MountIdentifier
+	7,  // 49: datawire.fuseftp.MountRequest.UserCredentialsEntry.value:type_name -> datawire.fuseftp.Credentials
+	20, // 50: datawire.fuseftp.MountStats.OperationsEntry.value:type_name -> datawire.fuseftp.OperationStats
+	33, // 51: datawire.fuseftp.FuseFTP.Version:input_type -> google.protobuf.Empty
+	12, // 52: datawire.fuseftp.FuseFTP.Mount:input_type -> datawire.fuseftp.MountRequest
+	4,  // 53: datawire.fuseftp.FuseFTP.Unmount:input_type -> datawire.fuseftp.MountIdentifier
+	5,  // 54: datawire.fuseftp.FuseFTP.SetFtpServer:input_type -> datawire.fuseftp.SetFtpServerRequest
+	10, // 55: datawire.fuseftp.FuseFTP.SetRateLimits:input_type -> datawire.fuseftp.SetRateLimitsRequest
+	33, // 56: datawire.fuseftp.FuseFTP.ListMounts:input_type -> google.protobuf.Empty
+	4,  // 57: datawire.fuseftp.FuseFTP.GetMount:input_type -> datawire.fuseftp.MountIdentifier
+	17, // 58: datawire.fuseftp.FuseFTP.WatchEvents:input_type -> datawire.fuseftp.WatchEventsRequest
+	4,  // 59: datawire.fuseftp.FuseFTP.GetStats:input_type -> datawire.fuseftp.MountIdentifier
+	23, // 60: datawire.fuseftp.FuseFTP.ConfigureTracing:input_type -> datawire.fuseftp.TracingConfig
+	24, // 61: datawire.fuseftp.FuseFTP.SetLogLevel:input_type -> datawire.fuseftp.SetLogLevelRequest
+	9,  // 62: datawire.fuseftp.FuseFTP.UpdateMount:input_type -> datawire.fuseftp.UpdateMountRequest
+	2,  // 63: datawire.fuseftp.FuseFTP.Version:output_type -> datawire.fuseftp.VersionInfo
+	4,  // 64: datawire.fuseftp.FuseFTP.Mount:output_type -> datawire.fuseftp.MountIdentifier
+	33, // 65: datawire.fuseftp.FuseFTP.Unmount:output_type -> google.protobuf.Empty
+	33, // 66: datawire.fuseftp.FuseFTP.SetFtpServer:output_type -> google.protobuf.Empty
+	33, // 67: datawire.fuseftp.FuseFTP.SetRateLimits:output_type -> google.protobuf.Empty
+	16, // 68: datawire.fuseftp.FuseFTP.ListMounts:output_type -> datawire.fuseftp.MountList
+	15, // 69: datawire.fuseftp.FuseFTP.GetMount:output_type -> datawire.fuseftp.MountInfo
+	18, // 70: datawire.fuseftp.FuseFTP.WatchEvents:output_type -> datawire.fuseftp.MountEvent
+	22, // 71: datawire.fuseftp.FuseFTP.GetStats:output_type -> datawire.fuseftp.MountStats
+	33, // 72: datawire.fuseftp.FuseFTP.ConfigureTracing:output_type -> google.protobuf.Empty
+	33, // 73: datawire.fuseftp.FuseFTP.SetLogLevel:output_type -> google.protobuf.Empty
+	15, // 74: datawire.fuseftp.FuseFTP.UpdateMount:output_type -> datawire.fuseftp.MountInfo
+	63, // [63:75] is the sub-list for method output_type
+	51, // [51:63] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
+}
+
+func init() { file_rpc_fuseftp_proto_init() }
+func file_rpc_fuseftp_proto_init() {
+	if File_rpc_fuseftp_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_fuseftp_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
 			default:
 				return nil
 			}
@@ -472,6 +2652,78 @@ func file_rpc_fuseftp_proto_init() {
 			}
 		}
 		file_rpc_fuseftp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RateLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Credentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolLimits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateMountRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetRateLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MountRequest); i {
 			case 0:
 				return &v.state
@@ -483,19 +2735,167 @@ func file_rpc_fuseftp_proto_init() {
 				return nil
 			}
 		}
+		file_rpc_fuseftp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Identity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FuseOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MountInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MountList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MountEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatencyBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperationStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PoolStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MountStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TracingConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_fuseftp_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetLogLevelRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
+	file_rpc_fuseftp_proto_msgTypes[7].OneofWrappers = []interface{}{}
+	file_rpc_fuseftp_proto_msgTypes[11].OneofWrappers = []interface{}{}
+	file_rpc_fuseftp_proto_msgTypes[12].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_fuseftp_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_rpc_fuseftp_proto_goTypes,
 		DependencyIndexes: file_rpc_fuseftp_proto_depIdxs,
+		EnumInfos:         file_rpc_fuseftp_proto_enumTypes,
 		MessageInfos:      file_rpc_fuseftp_proto_msgTypes,
 	}.Build()
 	File_rpc_fuseftp_proto = out.File
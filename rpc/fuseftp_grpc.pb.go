@@ -20,10 +20,18 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	FuseFTP_Version_FullMethodName      = "/datawire.fuseftp.FuseFTP/Version"
-	FuseFTP_Mount_FullMethodName        = "/datawire.fuseftp.FuseFTP/Mount"
-	FuseFTP_Unmount_FullMethodName      = "/datawire.fuseftp.FuseFTP/Unmount"
-	FuseFTP_SetFtpServer_FullMethodName = "/datawire.fuseftp.FuseFTP/SetFtpServer"
+	FuseFTP_Version_FullMethodName          = "/datawire.fuseftp.FuseFTP/Version"
+	FuseFTP_Mount_FullMethodName            = "/datawire.fuseftp.FuseFTP/Mount"
+	FuseFTP_Unmount_FullMethodName          = "/datawire.fuseftp.FuseFTP/Unmount"
+	FuseFTP_SetFtpServer_FullMethodName     = "/datawire.fuseftp.FuseFTP/SetFtpServer"
+	FuseFTP_SetRateLimits_FullMethodName    = "/datawire.fuseftp.FuseFTP/SetRateLimits"
+	FuseFTP_ListMounts_FullMethodName       = "/datawire.fuseftp.FuseFTP/ListMounts"
+	FuseFTP_GetMount_FullMethodName         = "/datawire.fuseftp.FuseFTP/GetMount"
+	FuseFTP_WatchEvents_FullMethodName      = "/datawire.fuseftp.FuseFTP/WatchEvents"
+	FuseFTP_GetStats_FullMethodName         = "/datawire.fuseftp.FuseFTP/GetStats"
+	FuseFTP_ConfigureTracing_FullMethodName = "/datawire.fuseftp.FuseFTP/ConfigureTracing"
+	FuseFTP_SetLogLevel_FullMethodName      = "/datawire.fuseftp.FuseFTP/SetLogLevel"
+	FuseFTP_UpdateMount_FullMethodName      = "/datawire.fuseftp.FuseFTP/UpdateMount"
 )
 
 // FuseFTPClient is the client API for FuseFTP service.
@@ -38,6 +46,28 @@ type FuseFTPClient interface {
 	Unmount(ctx context.Context, in *MountIdentifier, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// SetFtpServer changes the FTP server for a given mount identifier
 	SetFtpServer(ctx context.Context, in *SetFtpServerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SetRateLimits changes the bandwidth limits for a given mount identifier
+	SetRateLimits(ctx context.Context, in *SetRateLimitsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListMounts returns information about all current mounts
+	ListMounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MountList, error)
+	// GetMount returns information about the mount with the given identifier
+	GetMount(ctx context.Context, in *MountIdentifier, opts ...grpc.CallOption) (*MountInfo, error)
+	// WatchEvents streams the events of all mounts, or of the mount given in the request.
+	// Events are discarded when the client doesn't keep up.
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (FuseFTP_WatchEventsClient, error)
+	// GetStats returns the statistics of the mount with the given identifier
+	GetStats(ctx context.Context, in *MountIdentifier, opts ...grpc.CallOption) (*MountStats, error)
+	// ConfigureTracing replaces the OpenTelemetry tracing configuration of the daemon. Spans are
+	// always appended to the trace file of the daemon, if any. Other tracing is disabled when no
+	// OTLP endpoint is given.
+	ConfigureTracing(ctx context.Context, in *TracingConfig, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// SetLogLevel changes the log level of one mount. When no mount is identified, it changes
+	// the level of the daemon and of all mounts that have no level of their own.
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// UpdateMount changes the settings of a mount. Settings that are unset in the request are
+	// left unchanged. Connections that use outdated settings are replaced once they are idle,
+	// so ongoing transfers are not interrupted. The updated MountInfo is returned.
+	UpdateMount(ctx context.Context, in *UpdateMountRequest, opts ...grpc.CallOption) (*MountInfo, error)
 }
 
 type fuseFTPClient struct {
@@ -84,6 +114,101 @@ func (c *fuseFTPClient) SetFtpServer(ctx context.Context, in *SetFtpServerReques
 	return out, nil
 }
 
+func (c *fuseFTPClient) SetRateLimits(ctx context.Context, in *SetRateLimitsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FuseFTP_SetRateLimits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) ListMounts(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*MountList, error) {
+	out := new(MountList)
+	err := c.cc.Invoke(ctx, FuseFTP_ListMounts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) GetMount(ctx context.Context, in *MountIdentifier, opts ...grpc.CallOption) (*MountInfo, error) {
+	out := new(MountInfo)
+	err := c.cc.Invoke(ctx, FuseFTP_GetMount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (FuseFTP_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FuseFTP_ServiceDesc.Streams[0], FuseFTP_WatchEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fuseFTPWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FuseFTP_WatchEventsClient interface {
+	Recv() (*MountEvent, error)
+	grpc.ClientStream
+}
+
+type fuseFTPWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *fuseFTPWatchEventsClient) Recv() (*MountEvent, error) {
+	m := new(MountEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fuseFTPClient) GetStats(ctx context.Context, in *MountIdentifier, opts ...grpc.CallOption) (*MountStats, error) {
+	out := new(MountStats)
+	err := c.cc.Invoke(ctx, FuseFTP_GetStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) ConfigureTracing(ctx context.Context, in *TracingConfig, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FuseFTP_ConfigureTracing_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, FuseFTP_SetLogLevel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fuseFTPClient) UpdateMount(ctx context.Context, in *UpdateMountRequest, opts ...grpc.CallOption) (*MountInfo, error) {
+	out := new(MountInfo)
+	err := c.cc.Invoke(ctx, FuseFTP_UpdateMount_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FuseFTPServer is the server API for FuseFTP service.
 // All implementations must embed UnimplementedFuseFTPServer
 // for forward compatibility
@@ -96,6 +221,28 @@ type FuseFTPServer interface {
 	Unmount(context.Context, *MountIdentifier) (*emptypb.Empty, error)
 	// SetFtpServer changes the FTP server for a given mount identifier
 	SetFtpServer(context.Context, *SetFtpServerRequest) (*emptypb.Empty, error)
+	// SetRateLimits changes the bandwidth limits for a given mount identifier
+	SetRateLimits(context.Context, *SetRateLimitsRequest) (*emptypb.Empty, error)
+	// ListMounts returns information about all current mounts
+	ListMounts(context.Context, *emptypb.Empty) (*MountList, error)
+	// GetMount returns information about the mount with the given identifier
+	GetMount(context.Context, *MountIdentifier) (*MountInfo, error)
+	// WatchEvents streams the events of all mounts, or of the mount given in the request.
+	// Events are discarded when the client doesn't keep up.
+	WatchEvents(*WatchEventsRequest, FuseFTP_WatchEventsServer) error
+	// GetStats returns the statistics of the mount with the given identifier
+	GetStats(context.Context, *MountIdentifier) (*MountStats, error)
+	// ConfigureTracing replaces the OpenTelemetry tracing configuration of the daemon. Spans are
+	// always appended to the trace file of the daemon, if any. Other tracing is disabled when no
+	// OTLP endpoint is given.
+	ConfigureTracing(context.Context, *TracingConfig) (*emptypb.Empty, error)
+	// SetLogLevel changes the log level of one mount. When no mount is identified, it changes
+	// the level of the daemon and of all mounts that have no level of their own.
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*emptypb.Empty, error)
+	// UpdateMount changes the settings of a mount. Settings that are unset in the request are
+	// left unchanged. Connections that use outdated settings are replaced once they are idle,
+	// so ongoing transfers are not interrupted. The updated MountInfo is returned.
+	UpdateMount(context.Context, *UpdateMountRequest) (*MountInfo, error)
 	mustEmbedUnimplementedFuseFTPServer()
 }
 
@@ -115,6 +262,30 @@ func (UnimplementedFuseFTPServer) Unmount(context.Context, *MountIdentifier) (*e
 func (UnimplementedFuseFTPServer) SetFtpServer(context.Context, *SetFtpServerRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetFtpServer not implemented")
 }
+func (UnimplementedFuseFTPServer) SetRateLimits(context.Context, *SetRateLimitsRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRateLimits not implemented")
+}
+func (UnimplementedFuseFTPServer) ListMounts(context.Context, *emptypb.Empty) (*MountList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMounts not implemented")
+}
+func (UnimplementedFuseFTPServer) GetMount(context.Context, *MountIdentifier) (*MountInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMount not implemented")
+}
+func (UnimplementedFuseFTPServer) WatchEvents(*WatchEventsRequest, FuseFTP_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedFuseFTPServer) GetStats(context.Context, *MountIdentifier) (*MountStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedFuseFTPServer) ConfigureTracing(context.Context, *TracingConfig) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfigureTracing not implemented")
+}
+func (UnimplementedFuseFTPServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedFuseFTPServer) UpdateMount(context.Context, *UpdateMountRequest) (*MountInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateMount not implemented")
+}
 func (UnimplementedFuseFTPServer) mustEmbedUnimplementedFuseFTPServer() {}
 
 // UnsafeFuseFTPServer may be embedded to opt out of forward compatibility for this service.
@@ -200,6 +371,153 @@ func _FuseFTP_SetFtpServer_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FuseFTP_SetRateLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRateLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).SetRateLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_SetRateLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).SetRateLimits(ctx, req.(*SetRateLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_ListMounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).ListMounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_ListMounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).ListMounts(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_GetMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MountIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).GetMount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_GetMount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).GetMount(ctx, req.(*MountIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FuseFTPServer).WatchEvents(m, &fuseFTPWatchEventsServer{stream})
+}
+
+type FuseFTP_WatchEventsServer interface {
+	Send(*MountEvent) error
+	grpc.ServerStream
+}
+
+type fuseFTPWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *fuseFTPWatchEventsServer) Send(m *MountEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FuseFTP_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MountIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).GetStats(ctx, req.(*MountIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_ConfigureTracing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TracingConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).ConfigureTracing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_ConfigureTracing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).ConfigureTracing(ctx, req.(*TracingConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FuseFTP_UpdateMount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateMountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FuseFTPServer).UpdateMount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FuseFTP_UpdateMount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FuseFTPServer).UpdateMount(ctx, req.(*UpdateMountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FuseFTP_ServiceDesc is the grpc.ServiceDesc for FuseFTP service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -223,7 +541,41 @@ var FuseFTP_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetFtpServer",
 			Handler:    _FuseFTP_SetFtpServer_Handler,
 		},
+		{
+			MethodName: "SetRateLimits",
+			Handler:    _FuseFTP_SetRateLimits_Handler,
+		},
+		{
+			MethodName: "ListMounts",
+			Handler:    _FuseFTP_ListMounts_Handler,
+		},
+		{
+			MethodName: "GetMount",
+			Handler:    _FuseFTP_GetMount_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _FuseFTP_GetStats_Handler,
+		},
+		{
+			MethodName: "ConfigureTracing",
+			Handler:    _FuseFTP_ConfigureTracing_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _FuseFTP_SetLogLevel_Handler,
+		},
+		{
+			MethodName: "UpdateMount",
+			Handler:    _FuseFTP_UpdateMount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _FuseFTP_WatchEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/fuseftp.proto",
 }